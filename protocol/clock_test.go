@@ -0,0 +1,86 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvanceFiresInOrder(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := NewFakeClock(start)
+
+	var fired []string
+	var firedAt []time.Time
+	record := func(name string) func() {
+		return func() {
+			fired = append(fired, name)
+			firedAt = append(firedAt, clock.Now())
+		}
+	}
+
+	clock.AfterFunc(3*time.Second, record("c"))
+	clock.AfterFunc(time.Second, record("a"))
+	clock.AfterFunc(time.Second, record("b"))
+	clock.AfterFunc(time.Minute, record("late"))
+
+	clock.Advance(5 * time.Second)
+
+	if got := len(fired); got != 3 || fired[0] != "a" || fired[1] != "b" || fired[2] != "c" {
+		t.Fatalf("Expected [a b c], got %v", fired)
+	}
+	if !firedAt[0].Equal(start.Add(time.Second)) || !firedAt[2].Equal(start.Add(3*time.Second)) {
+		t.Errorf("Callbacks saw unexpected times: %v", firedAt)
+	}
+	if !clock.Now().Equal(start.Add(5 * time.Second)) {
+		t.Errorf("Expected clock at +5s, got %v", clock.Now())
+	}
+	if clock.Pending() != 1 {
+		t.Errorf("Expected 1 pending timer, got %d", clock.Pending())
+	}
+}
+
+func TestFakeClockStop(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	fired := false
+	timer := clock.AfterFunc(time.Second, func() { fired = true })
+
+	if !timer.Stop() {
+		t.Error("Expected Stop to report a pending timer")
+	}
+	if timer.Stop() {
+		t.Error("Expected second Stop to return false")
+	}
+
+	clock.Advance(time.Hour)
+	if fired {
+		t.Error("Stopped timer fired")
+	}
+
+	fired = false
+	timer = clock.AfterFunc(0, func() { fired = true })
+	clock.Advance(0)
+	if !fired {
+		t.Error("Expected zero-duration timer to fire on Advance")
+	}
+	if timer.Stop() {
+		t.Error("Expected Stop after firing to return false")
+	}
+}
+
+func TestFakeClockRunsTimersScheduledByCallbacks(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	count := 0
+	var tick func()
+	tick = func() {
+		count++
+		clock.AfterFunc(time.Second, tick)
+	}
+	clock.AfterFunc(time.Second, tick)
+
+	clock.Advance(3 * time.Second)
+	if count != 3 {
+		t.Errorf("Expected 3 ticks, got %d", count)
+	}
+}
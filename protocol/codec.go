@@ -0,0 +1,276 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Codec encodes and decodes protocol messages to and from their wire representation.
+//
+// JSON is the only encoding allowed by the MCP specification, so JSONCodec is
+// always registered and used by default. Alternative codecs (MessagePack, CBOR, ...)
+// can be registered for internal deployments where both peers agree on them.
+type Codec interface {
+	// ContentType returns the media type identifying this codec, e.g. "application/json".
+	ContentType() string
+	// Marshal encodes v into its wire representation.
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into v.
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONContentType is the media type of the spec-compliant JSON codec.
+const JSONContentType = "application/json"
+
+// jsonCodec is the default Codec backed by encoding/json.
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string {
+	return JSONContentType
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	if len(data) == 0 {
+		return ErrEmptyJSONData
+	}
+	return json.Unmarshal(data, v)
+}
+
+// JSONCodec is the spec-compliant JSON codec used when no other codec is negotiated.
+var JSONCodec Codec = jsonCodec{}
+
+// canonicalJSONCodec is a JSON codec with deterministic output.
+type canonicalJSONCodec struct {
+	jsonCodec
+}
+
+// Marshal encodes v as JSON with object keys sorted at every level, no insignificant
+// whitespace, no HTML escaping and numbers in a single form: 1, 1.0 and 1e0 all
+// encode as 1. Integer literals are kept digit for digit, so large IDs don't lose
+// precision; other numbers are written like encoding/json writes a float64,
+// which matches the number format of RFC 8785.
+func (canonicalJSONCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	if tree, err = canonicalizeNumbers(tree); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(tree); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// canonicalizeNumbers replaces every json.Number in a decoded tree with its canonical form.
+func canonicalizeNumbers(v interface{}) (interface{}, error) {
+	var err error
+	switch v := v.(type) {
+	case json.Number:
+		return canonicalNumber(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			if v[key], err = canonicalizeNumbers(item); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if v[i], err = canonicalizeNumbers(item); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// canonicalNumber returns the single textual form used for n by CanonicalJSONCodec.
+func canonicalNumber(n json.Number) (json.Number, error) {
+	text := n.String()
+	if !strings.ContainsAny(text, ".eE") {
+		if text == "-0" {
+			return "0", nil
+		}
+		return n, nil
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return "", fmt.Errorf("canonicalize number %s: %w", text, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return "", err
+	}
+	return json.Number(data), nil
+}
+
+// CanonicalJSONCodec emits canonical JSON: the same bytes for the same message,
+// regardless of struct field order or map iteration. Use it for outbound messages
+// when golden-file tests, signatures or caches depend on stable output.
+// It decodes exactly like JSONCodec and reports the same content type.
+//
+// Example:
+//
+//	data, err := protocol.CanonicalJSONCodec.Marshal(resp)
+var CanonicalJSONCodec Codec = canonicalJSONCodec{}
+
+// validatingCodec wraps a Codec and validates messages before encoding them.
+type validatingCodec struct {
+	Codec
+	onInvalid func(msg any, err error)
+}
+
+func (c validatingCodec) Marshal(v any) ([]byte, error) {
+	if err := ValidateMessage(v); err != nil && err != ErrUnsupportedMessage {
+		if c.onInvalid == nil {
+			return nil, err
+		}
+		c.onInvalid(v, err)
+	}
+	return c.Codec.Marshal(v)
+}
+
+// NewValidatingCodec returns a Codec that runs ValidateMessage on every
+// outgoing message before delegating to next.
+//
+// If onInvalid is nil, non-compliant messages are rejected with the validation error.
+// Otherwise onInvalid is called and the message is still encoded, which is useful
+// for logging violations in development without breaking the session.
+// Values that are not protocol messages are encoded without validation.
+//
+// Example:
+//
+//	codec := protocol.NewValidatingCodec(protocol.JSONCodec, func(msg any, err error) {
+//	    log.Printf("non-compliant outbound message %T: %v", msg, err)
+//	})
+func NewValidatingCodec(next Codec, onInvalid func(msg any, err error)) Codec {
+	if next == nil {
+		next = JSONCodec
+	}
+	return validatingCodec{Codec: next, onInvalid: onInvalid}
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{JSONContentType: JSONCodec}
+)
+
+// RegisterCodec makes a codec available for negotiation under its content type.
+//
+// Registering a codec with an already registered content type replaces it,
+// except for the JSON codec which cannot be replaced.
+//
+// Example:
+//
+//	protocol.RegisterCodec(msgpackCodec{})
+func RegisterCodec(c Codec) error {
+	if c == nil {
+		return ErrCodecNil
+	}
+	contentType := normalizeContentType(c.ContentType())
+	if contentType == "" {
+		return NewValidationError("codec content type cannot be empty")
+	}
+	if contentType == JSONContentType {
+		return NewValidationError("codec for %q cannot be replaced", JSONContentType)
+	}
+
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[contentType] = c
+	return nil
+}
+
+// LookupCodec returns the codec registered for the given content type.
+// Media type parameters such as "; charset=utf-8" are ignored.
+func LookupCodec(contentType string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[normalizeContentType(contentType)]
+	return c, ok
+}
+
+// NegotiateCodec selects a codec for a comma-separated list of acceptable
+// media types (as found in an HTTP Accept header).
+//
+// Types are ranked by their "q" parameter, which defaults to 1; types with equal
+// q keep the order given, and types with q=0 are never selected. "*/*" matches JSONCodec.
+// It falls back to JSONCodec when none of the listed types is registered,
+// so peers that don't know about alternative codecs always get JSON.
+//
+// Example:
+//
+//	codec := protocol.NegotiateCodec(r.Header.Get("Accept"))
+func NegotiateCodec(accept string) Codec {
+	var best Codec
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		q := acceptQuality(part)
+		if q <= bestQ {
+			continue
+		}
+
+		c, ok := LookupCodec(part)
+		if !ok && normalizeContentType(part) == "*/*" {
+			c, ok = JSONCodec, true
+		}
+		if ok {
+			best, bestQ = c, q
+		}
+	}
+
+	if best == nil {
+		return JSONCodec
+	}
+	return best
+}
+
+// acceptQuality returns the "q" parameter of a media range from an Accept header.
+// A missing q means 1; a malformed or out-of-range q means 0.
+func acceptQuality(mediaRange string) float64 {
+	params := strings.Split(mediaRange, ";")
+	for _, param := range params[1:] {
+		name, value, found := strings.Cut(param, "=")
+		if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || !(q >= 0 && q <= 1) {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+// normalizeContentType strips media type parameters and whitespace and lowercases the type.
+func normalizeContentType(contentType string) string {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
@@ -0,0 +1,208 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type testCodec struct {
+	contentType string
+}
+
+func (c testCodec) ContentType() string                { return c.contentType }
+func (c testCodec) Marshal(v any) ([]byte, error)      { return []byte("test"), nil }
+func (c testCodec) Unmarshal(data []byte, v any) error { return nil }
+
+func TestJSONCodecRoundTrip(t *testing.T) {
+	req := NewRequest("tools/list", nil, NewID("codec-1"))
+	data, err := JSONCodec.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded jsonRPCRequest[string]
+	if err := JSONCodec.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Method != "tools/list" || decoded.ID.Value != "codec-1" {
+		t.Errorf("Unexpected decoded request: %+v", decoded)
+	}
+	if JSONCodec.ContentType() != JSONContentType {
+		t.Errorf("Expected %q, got %q", JSONContentType, JSONCodec.ContentType())
+	}
+}
+
+func TestJSONCodecRejectsEmptyData(t *testing.T) {
+	var v json.RawMessage
+	if err := JSONCodec.Unmarshal(nil, &v); err != ErrEmptyJSONData {
+		t.Errorf("Expected ErrEmptyJSONData, got %v", err)
+	}
+}
+
+func TestRegisterCodecValidation(t *testing.T) {
+	if err := RegisterCodec(nil); err != ErrCodecNil {
+		t.Errorf("Expected ErrCodecNil, got %v", err)
+	}
+
+	var vErr *ValidationError
+	if err := RegisterCodec(testCodec{contentType: " "}); !errors.As(err, &vErr) {
+		t.Errorf("Expected ValidationError for empty content type, got %v", err)
+	}
+	if err := RegisterCodec(testCodec{contentType: "application/JSON"}); !errors.As(err, &vErr) {
+		t.Errorf("Expected ValidationError when replacing JSON codec, got %v", err)
+	}
+}
+
+func TestRegisterAndLookupCodec(t *testing.T) {
+	codec := testCodec{contentType: "application/x-test-lookup"}
+	if err := RegisterCodec(codec); err != nil {
+		t.Fatalf("RegisterCodec failed: %v", err)
+	}
+
+	got, ok := LookupCodec("Application/X-Test-Lookup; charset=utf-8")
+	if !ok || got != codec {
+		t.Errorf("Expected registered codec, got %v (found=%v)", got, ok)
+	}
+
+	if _, ok := LookupCodec("application/unknown"); ok {
+		t.Error("Expected lookup of unknown content type to fail")
+	}
+}
+
+func TestNegotiateCodec(t *testing.T) {
+	codec := testCodec{contentType: "application/x-test-negotiate"}
+	if err := RegisterCodec(codec); err != nil {
+		t.Fatalf("RegisterCodec failed: %v", err)
+	}
+
+	tests := []struct {
+		accept   string
+		expected Codec
+	}{
+		{"", JSONCodec},
+		{"text/event-stream", JSONCodec},
+		{"application/x-test-negotiate, application/json", codec},
+		{"application/json, application/x-test-negotiate", JSONCodec},
+		{"text/plain, application/x-test-negotiate;q=0.9", codec},
+		{"application/json;q=0.5, application/x-test-negotiate", codec},
+		{"application/x-test-negotiate;q=0.4, application/json;q=0.8", JSONCodec},
+		{"application/x-test-negotiate;q=0, text/plain", JSONCodec},
+		{"application/x-test-negotiate; q=0.000, application/json;q=0.1", JSONCodec},
+		{"application/x-test-negotiate;q=0.5, */*;q=0.1", codec},
+		{"application/x-test-negotiate;q=0.1, */*", JSONCodec},
+		{"application/x-test-negotiate;q=2", JSONCodec},
+		{"application/x-test-negotiate;q=abc, application/json;q=0.1", JSONCodec},
+		{"application/x-test-negotiate;Q=0.7, application/json;q=0.6", codec},
+	}
+
+	for _, test := range tests {
+		if got := NegotiateCodec(test.accept); got != test.expected {
+			t.Errorf("NegotiateCodec(%q): expected %v, got %v", test.accept, test.expected, got)
+		}
+	}
+}
+
+func TestValidatingCodecRejectsInvalidMessages(t *testing.T) {
+	codec := NewValidatingCodec(JSONCodec, nil)
+
+	if _, err := codec.Marshal(NewResponse("req-1", nil)); err == nil {
+		t.Error("Expected invalid response to be rejected")
+	}
+
+	data, err := codec.Marshal(NewResponse("req-1", "ok"))
+	if err != nil {
+		t.Fatalf("Expected valid response to be encoded, got %v", err)
+	}
+	if string(data) != `{"jsonrpc":"2.0","id":"req-1","result":"ok"}` {
+		t.Errorf("Unexpected encoding: %s", data)
+	}
+
+	if _, err := codec.Marshal(map[string]int{"a": 1}); err != nil {
+		t.Errorf("Expected non-message values to pass through, got %v", err)
+	}
+}
+
+func TestValidatingCodecReportsInvalidMessages(t *testing.T) {
+	var reported error
+	codec := NewValidatingCodec(nil, func(msg any, err error) {
+		reported = err
+	})
+
+	if codec.ContentType() != JSONContentType {
+		t.Errorf("Expected nil codec to default to JSON, got %q", codec.ContentType())
+	}
+
+	data, err := codec.Marshal(NewNotification("rpc.reserved", nil))
+	if err != nil {
+		t.Fatalf("Expected message to still be encoded, got %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("Expected encoded data")
+	}
+	if reported == nil {
+		t.Error("Expected onInvalid to be called")
+	}
+}
+
+func TestCanonicalJSONCodec(t *testing.T) {
+	type params struct {
+		Zeta  string            `json:"zeta"`
+		Alpha map[string]any    `json:"alpha"`
+		Query string            `json:"query"`
+		Extra map[string]string `json:"extra,omitempty"`
+	}
+	req := NewRequest("tools/call", params{
+		Zeta:  "<z>",
+		Alpha: map[string]any{"b": 1.50, "a": []int{3, 1}},
+		Query: "a & b",
+	}, NewID("canon"))
+
+	data, err := CanonicalJSONCodec.Marshal(req)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"id":"canon","jsonrpc":"2.0","method":"tools/call","params":{"alpha":{"a":[3,1],"b":1.5},"query":"a & b","zeta":"<z>"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+
+	var decoded jsonRPCRequest[string]
+	if err := CanonicalJSONCodec.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if CanonicalJSONCodec.ContentType() != JSONContentType {
+		t.Errorf("Expected %q, got %q", JSONContentType, CanonicalJSONCodec.ContentType())
+	}
+}
+
+func TestCanonicalJSONCodecNormalizesNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"big":12345678901234567890,"f":1.0}`, `{"big":12345678901234567890,"f":1}`},
+		{`[1,1.0,1e0,10e-1,100E-2]`, `[1,1,1,1,1]`},
+		{`[-0,-0.0,0e5]`, `[0,0,0]`},
+		{`[1.50,15e-1,0.000001,1.5e-7,1e20,1e21]`, `[1.5,1.5,0.000001,1.5e-7,100000000000000000000,1e+21]`},
+		{`{"nested":{"a":[2.50E+1]}}`, `{"nested":{"a":[25]}}`},
+	}
+
+	for _, test := range tests {
+		data, err := CanonicalJSONCodec.Marshal(json.RawMessage(test.input))
+		if err != nil {
+			t.Fatalf("Marshal(%s) failed: %v", test.input, err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("Marshal(%s): expected %s, got %s", test.input, test.expected, data)
+		}
+	}
+
+	if _, err := CanonicalJSONCodec.Marshal(json.RawMessage(`[1e400]`)); err == nil {
+		t.Error("Expected error for a number outside the float64 range")
+	}
+
+	if _, err := CanonicalJSONCodec.Marshal(make(chan int)); err == nil {
+		t.Error("Expected error for unsupported value")
+	}
+}
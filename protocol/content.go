@@ -0,0 +1,210 @@
+package protocol
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Content block types defined by the MCP specification.
+const (
+	ContentTypeText     = "text"
+	ContentTypeImage    = "image"
+	ContentTypeAudio    = "audio"
+	ContentTypeResource = "resource"
+)
+
+// Content is a content block in tool results, prompt messages and sampling messages.
+//
+// It is implemented by TextContent, ImageContent, AudioContent and EmbeddedResource.
+// Each implementation adds the matching "type" field when marshaled.
+type Content interface {
+	// ContentType returns the value of the block's "type" field.
+	ContentType() string
+}
+
+// TextContent is a plain text content block.
+type TextContent struct {
+	Text        string       `json:"text"`
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// ContentType implements Content.
+func (TextContent) ContentType() string { return ContentTypeText }
+
+// MarshalJSON adds the "type" field to the encoded block.
+func (c TextContent) MarshalJSON() ([]byte, error) {
+	type content TextContent
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		content
+	}{Type: ContentTypeText, content: content(c)})
+}
+
+// ImageContent is an image content block with base64-encoded data.
+type ImageContent struct {
+	// Data is the base64-encoded image.
+	Data        string       `json:"data"`
+	MimeType    string       `json:"mimeType"`
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// ContentType implements Content.
+func (ImageContent) ContentType() string { return ContentTypeImage }
+
+// MarshalJSON adds the "type" field to the encoded block.
+func (c ImageContent) MarshalJSON() ([]byte, error) {
+	type content ImageContent
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		content
+	}{Type: ContentTypeImage, content: content(c)})
+}
+
+// Bytes decodes the base64 image data.
+func (c ImageContent) Bytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(c.Data)
+}
+
+// AudioContent is an audio content block with base64-encoded data.
+type AudioContent struct {
+	// Data is the base64-encoded audio.
+	Data        string       `json:"data"`
+	MimeType    string       `json:"mimeType"`
+	Annotations *Annotations `json:"annotations,omitempty"`
+}
+
+// ContentType implements Content.
+func (AudioContent) ContentType() string { return ContentTypeAudio }
+
+// MarshalJSON adds the "type" field to the encoded block.
+func (c AudioContent) MarshalJSON() ([]byte, error) {
+	type content AudioContent
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		content
+	}{Type: ContentTypeAudio, content: content(c)})
+}
+
+// Bytes decodes the base64 audio data.
+func (c AudioContent) Bytes() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(c.Data)
+}
+
+// ResourceContents are the contents of a resource, either text or base64-encoded binary.
+// Exactly one of Text or Blob should be set.
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	// Blob is the base64-encoded binary content.
+	Blob string `json:"blob,omitempty"`
+}
+
+// EmbeddedResource is a content block carrying the contents of a resource inline.
+type EmbeddedResource struct {
+	Resource    ResourceContents `json:"resource"`
+	Annotations *Annotations     `json:"annotations,omitempty"`
+}
+
+// ContentType implements Content.
+func (EmbeddedResource) ContentType() string { return ContentTypeResource }
+
+// MarshalJSON adds the "type" field to the encoded block.
+func (c EmbeddedResource) MarshalJSON() ([]byte, error) {
+	type content EmbeddedResource
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		content
+	}{Type: ContentTypeResource, content: content(c)})
+}
+
+// NewTextContent creates a text content block.
+func NewTextContent(text string) TextContent {
+	return TextContent{Text: text}
+}
+
+// NewImageContent creates an image content block, base64-encoding data.
+//
+// Example:
+//
+//	img := protocol.NewImageContent(pngBytes, "image/png")
+func NewImageContent(data []byte, mimeType string) ImageContent {
+	return ImageContent{Data: base64.StdEncoding.EncodeToString(data), MimeType: mimeType}
+}
+
+// NewAudioContent creates an audio content block, base64-encoding data.
+func NewAudioContent(data []byte, mimeType string) AudioContent {
+	return AudioContent{Data: base64.StdEncoding.EncodeToString(data), MimeType: mimeType}
+}
+
+// NewEmbeddedResource creates a content block embedding the given resource contents.
+func NewEmbeddedResource(contents ResourceContents) EmbeddedResource {
+	return EmbeddedResource{Resource: contents}
+}
+
+// UnmarshalContent decodes a single content block, choosing the concrete type from its "type" field.
+//
+// Returns ErrUnsupportedContentType for unknown block types.
+func UnmarshalContent(data []byte) (Content, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyJSONData
+	}
+
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	var (
+		content Content
+		err     error
+	)
+	switch head.Type {
+	case ContentTypeText:
+		var c TextContent
+		err = json.Unmarshal(data, &c)
+		content = c
+	case ContentTypeImage:
+		var c ImageContent
+		err = json.Unmarshal(data, &c)
+		content = c
+	case ContentTypeAudio:
+		var c AudioContent
+		err = json.Unmarshal(data, &c)
+		content = c
+	case ContentTypeResource:
+		var c EmbeddedResource
+		err = json.Unmarshal(data, &c)
+		content = c
+	default:
+		return nil, &UnsupportedContentTypeError{Type: head.Type}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// Contents is a list of content blocks that can be unmarshaled from JSON.
+type Contents []Content
+
+// UnmarshalJSON decodes each element with UnmarshalContent.
+func (c *Contents) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	contents := make(Contents, 0, len(raw))
+	for _, item := range raw {
+		content, err := UnmarshalContent(item)
+		if err != nil {
+			return err
+		}
+		contents = append(contents, content)
+	}
+	*c = contents
+	return nil
+}
@@ -0,0 +1,169 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestContentMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  Content
+		expected string
+	}{
+		{"Text", NewTextContent("hello"), `{"type":"text","text":"hello"}`},
+		{"Image", NewImageContent([]byte("png"), "image/png"), `{"type":"image","data":"cG5n","mimeType":"image/png"}`},
+		{"Audio", NewAudioContent([]byte("wav"), "audio/wav"), `{"type":"audio","data":"d2F2","mimeType":"audio/wav"}`},
+		{
+			"Resource",
+			NewEmbeddedResource(ResourceContents{URI: "file:///a.txt", MimeType: "text/plain", Text: "A"}),
+			`{"type":"resource","resource":{"uri":"file:///a.txt","mimeType":"text/plain","text":"A"}}`,
+		},
+		{
+			"TextWithAnnotations",
+			TextContent{Text: "hi", Annotations: &Annotations{Audience: []Role{RoleAssistant}}},
+			`{"type":"text","text":"hi","annotations":{"audience":["assistant"]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.content)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(data) != test.expected {
+				t.Errorf("Expected JSON %s, got %s", test.expected, data)
+			}
+
+			decoded, err := UnmarshalContent(data)
+			if err != nil {
+				t.Fatalf("UnmarshalContent failed: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, test.content) {
+				t.Errorf("Expected round trip to return %#v, got %#v", test.content, decoded)
+			}
+		})
+	}
+}
+
+func TestContentBytes(t *testing.T) {
+	data, err := NewImageContent([]byte{0, 1, 2}, "image/png").Bytes()
+	if err != nil || !reflect.DeepEqual(data, []byte{0, 1, 2}) {
+		t.Errorf("Unexpected image bytes: %v, %v", data, err)
+	}
+	data, err = NewAudioContent([]byte{3}, "audio/wav").Bytes()
+	if err != nil || !reflect.DeepEqual(data, []byte{3}) {
+		t.Errorf("Unexpected audio bytes: %v, %v", data, err)
+	}
+	if _, err := (ImageContent{Data: "%%%"}).Bytes(); err == nil {
+		t.Error("Expected error for invalid base64")
+	}
+}
+
+func TestUnmarshalContentErrors(t *testing.T) {
+	if _, err := UnmarshalContent(nil); err != ErrEmptyJSONData {
+		t.Errorf("Expected ErrEmptyJSONData, got %v", err)
+	}
+	if _, err := UnmarshalContent([]byte(`[`)); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if _, err := UnmarshalContent([]byte(`{"type":"text","text":1}`)); err == nil {
+		t.Error("Expected error for mistyped field")
+	}
+
+	_, err := UnmarshalContent([]byte(`{"type":"video"}`))
+	var ctErr *UnsupportedContentTypeError
+	if !errors.As(err, &ctErr) || ctErr.Type != "video" {
+		t.Errorf("Expected UnsupportedContentTypeError, got %v", err)
+	}
+	if !errors.Is(err, ErrUnsupportedContentType) {
+		t.Error("Expected errors.Is to match ErrUnsupportedContentType")
+	}
+}
+
+func TestCallToolResultRoundTrip(t *testing.T) {
+	result := NewToolResult(NewTextContent("done"), NewImageContent([]byte("png"), "image/png"))
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"content":[{"type":"text","text":"done"},{"type":"image","data":"cG5n","mimeType":"image/png"}]}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+
+	var decoded CallToolResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, result) {
+		t.Errorf("Expected %#v, got %#v", result, decoded)
+	}
+
+	if err := json.Unmarshal([]byte(`{"content":[{"type":"x"}]}`), &decoded); err == nil {
+		t.Error("Expected error for unsupported content type")
+	}
+	if err := json.Unmarshal([]byte(`{"content":{}}`), &decoded); err == nil {
+		t.Error("Expected error for non-array content")
+	}
+}
+
+func TestNewToolResultWithoutContent(t *testing.T) {
+	result := NewToolResult()
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if expected := `{"content":[]}`; string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+
+	var decoded CallToolResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, result) {
+		t.Errorf("Expected %#v, got %#v", result, decoded)
+	}
+}
+
+func TestMessagesRoundTrip(t *testing.T) {
+	prompt := GetPromptResult{Messages: []PromptMessage{
+		{Role: RoleUser, Content: NewTextContent("Review this")},
+		{Role: RoleAssistant, Content: NewEmbeddedResource(ResourceContents{URI: "file:///a.go", Blob: "AA=="})},
+	}}
+	data, err := json.Marshal(prompt)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decodedPrompt GetPromptResult
+	if err := json.Unmarshal(data, &decodedPrompt); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decodedPrompt, prompt) {
+		t.Errorf("Expected %#v, got %#v", prompt, decodedPrompt)
+	}
+
+	sampling := SamplingMessage{Role: RoleUser, Content: NewAudioContent([]byte("a"), "audio/wav")}
+	data, err = json.Marshal(sampling)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decodedSampling SamplingMessage
+	if err := json.Unmarshal(data, &decodedSampling); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decodedSampling, sampling) {
+		t.Errorf("Expected %#v, got %#v", sampling, decodedSampling)
+	}
+
+	if err := json.Unmarshal([]byte(`{"role":"user"}`), &decodedSampling); err == nil {
+		t.Error("Expected error for missing content")
+	}
+	if err := json.Unmarshal([]byte(`{"role":1}`), &decodedSampling); err == nil {
+		t.Error("Expected error for invalid role")
+	}
+}
@@ -3,6 +3,8 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -66,3 +68,38 @@ func TestNewInvalidIDError(t *testing.T) {
 		t.Errorf("Expected '%s', got: %s", expected, err.Error())
 	}
 }
+
+func TestStructuredCapabilityErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *RPCError
+		code     int
+		contains string
+		data     map[string]string
+	}{
+		{"NotInitialized", NewServerNotInitializedError("tools/list"), ServerNotInitialized, "not initialized", map[string]string{"method": "tools/list"}},
+		{"NotNegotiated", NewCapabilityNotNegotiatedError("roots/list", "roots"), CapabilityNotNegotiated, "not negotiated", map[string]string{"method": "roots/list", "capability": "roots"}},
+		{"Disabled", NewCapabilityDisabledError("tools/call", "tools"), CapabilityDisabled, "disabled", map[string]string{"method": "tools/call", "capability": "tools"}},
+		{"ResourceNotFound", NewResourceNotFoundError("file:///missing"), ResourceNotFound, "not found", map[string]string{"uri": "file:///missing"}},
+	}
+
+	for _, test := range tests {
+		if test.err.Code != test.code {
+			t.Errorf("%s: expected code %d, got %d", test.name, test.code, test.err.Code)
+		}
+		if !strings.Contains(test.err.Message, test.contains) {
+			t.Errorf("%s: expected message to contain %q, got %q", test.name, test.contains, test.err.Message)
+		}
+		if !reflect.DeepEqual(test.err.Data, test.data) {
+			t.Errorf("%s: expected data %v, got %v", test.name, test.data, test.err.Data)
+		}
+	}
+
+	codes := map[int]bool{}
+	for _, code := range []int{CapabilityDisabled, ResourceNotFound, ServerNotInitialized, CapabilityNotNegotiated, ReadOnlyMode} {
+		if codes[code] {
+			t.Errorf("Duplicate error code %d", code)
+		}
+		codes[code] = true
+	}
+}
@@ -3,6 +3,7 @@ package protocol
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // === Error Variables ===
@@ -34,6 +35,22 @@ var (
 	// ErrSoftTimeoutExceedsMaximum is returned when the soft timeout exceeds or equals the maximum timeout.
 	ErrSoftTimeoutExceedsMaximum = errors.New("soft timeout exceeds or equals maximum timeout")
 
+	// ErrIdleTimeoutNotPositive is returned when the idle timeout is not positive.
+	ErrIdleTimeoutNotPositive = errors.New("idle timeout must be greater than zero")
+
+	// ErrIdleTimeoutExceedsMaximum is returned when the idle timeout is not shorter than the maximum timeout.
+	ErrIdleTimeoutExceedsMaximum = errors.New("idle timeout exceeds or equals maximum timeout")
+
+	// ErrNoTimeoutStages is returned when a staged request is started without any stages.
+	ErrNoTimeoutStages = errors.New("at least one timeout stage is required")
+
+	// ErrStagedRequest is returned by UpdateCallback for requests started with StartStagedRequest,
+	// whose stage callback cannot be replaced by a TimeoutType callback.
+	ErrStagedRequest = errors.New("request uses timeout stages")
+
+	// ErrTimeoutStagesNotOrdered is returned when stage durations are not positive and strictly increasing.
+	ErrTimeoutStagesNotOrdered = errors.New("timeout stages must have positive, strictly increasing durations")
+
 	// ErrDuplicateRequestID is returned when a request with the same ID has already been started in this session.
 	//
 	// Example:
@@ -63,6 +80,18 @@ var (
 
 	// ErrUnsupportedMessageType is returned when message type could not be determined
 	ErrUnsupportedMessageType = errors.New("unsupported or unrecognized message type")
+
+	// ErrCodecNil is returned when a nil codec is registered.
+	ErrCodecNil = errors.New("codec must not be nil")
+
+	// ErrUnsupportedContentType is returned when a content block has an unknown "type".
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+
+	// ErrNoModelAvailable is returned when no model satisfies the sampling model preferences.
+	ErrNoModelAvailable = errors.New("no model available for the given preferences")
+
+	// ErrMissingCapability is returned when the peer did not declare a capability the caller requires.
+	ErrMissingCapability = errors.New("required capability not declared")
 )
 
 // === JSON-RPC Error Codes ===
@@ -75,8 +104,17 @@ const (
 	InternalError  = -32603
 
 	// Server-defined errors
+
+	// CapabilityDisabled is returned when a capability exists but was turned off by server configuration.
 	CapabilityDisabled = -32001
-	ResourceNotFound   = -32002
+	// ResourceNotFound is returned when a requested resource URI is unknown.
+	ResourceNotFound = -32002
+	// ServerNotInitialized is returned for requests other than initialize and ping received before initialization completes.
+	ServerNotInitialized = -32003
+	// CapabilityNotNegotiated is returned when a method needs a capability the peer didn't declare during initialization.
+	CapabilityNotNegotiated = -32004
+	// ReadOnlyMode is returned when a tool that is not read-only is called while the server runs in read-only mode.
+	ReadOnlyMode = -32005
 )
 
 // === Custom Error Types ===
@@ -121,6 +159,50 @@ func (e *InvalidIDError) Is(target error) bool {
 	return target == ErrInvalidID
 }
 
+// UnsupportedContentTypeError wraps ErrUnsupportedContentType with the offending type.
+//
+// Example:
+//
+//	var ctErr *protocol.UnsupportedContentTypeError
+//	if errors.As(err, &ctErr) {
+//		log.Printf("unknown content block %q", ctErr.Type)
+//	}
+type UnsupportedContentTypeError struct {
+	Type string
+}
+
+// Error implements the error interface.
+func (e *UnsupportedContentTypeError) Error() string {
+	return fmt.Sprintf("%v: %q", ErrUnsupportedContentType, e.Type)
+}
+
+// Unwrap allows errors.Is to match ErrUnsupportedContentType.
+func (e *UnsupportedContentTypeError) Unwrap() error {
+	return ErrUnsupportedContentType
+}
+
+// MissingCapabilitiesError wraps ErrMissingCapability with every capability the server lacks.
+//
+// Example:
+//
+//	var capErr *protocol.MissingCapabilitiesError
+//	if errors.As(err, &capErr) {
+//		log.Printf("server lacks %v, disabling those features", capErr.Missing)
+//	}
+type MissingCapabilitiesError struct {
+	Missing []string
+}
+
+// Error implements the error interface.
+func (e *MissingCapabilitiesError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrMissingCapability, strings.Join(e.Missing, ", "))
+}
+
+// Unwrap allows errors.Is to match ErrMissingCapability.
+func (e *MissingCapabilitiesError) Unwrap() error {
+	return ErrMissingCapability
+}
+
 // === Error Factory ===
 
 // NewValidationError creates a new ValidationError with a formatted reason.
@@ -132,3 +214,53 @@ func NewValidationError(format string, args ...interface{}) *ValidationError {
 func NewInvalidIDError(format string, args ...interface{}) *InvalidIDError {
 	return &InvalidIDError{Err: fmt.Errorf(format, args...)}
 }
+
+// NewServerNotInitializedError creates the RPCError for a request received before initialization completed.
+//
+// Example:
+//
+//	if !session.Initialized() && req.GetMethod() != protocol.MethodInitialize {
+//	    return protocol.NewServerNotInitializedError(req.GetMethod())
+//	}
+func NewServerNotInitializedError(method string) *RPCError {
+	return NewRPCError(
+		ServerNotInitialized,
+		fmt.Sprintf("Server not initialized: %q requires a completed initialize handshake", method),
+		map[string]string{"method": method},
+	)
+}
+
+// NewCapabilityNotNegotiatedError creates the RPCError for a method whose capability
+// was not declared by the peer during initialization.
+func NewCapabilityNotNegotiatedError(method, capability string) *RPCError {
+	return NewRPCError(
+		CapabilityNotNegotiated,
+		fmt.Sprintf("Capability not negotiated: %q requires the %q capability, which was not declared during initialization", method, capability),
+		map[string]string{"method": method, "capability": capability},
+	)
+}
+
+// NewCapabilityDisabledError creates the RPCError for a capability turned off by server configuration.
+func NewCapabilityDisabledError(method, capability string) *RPCError {
+	return NewRPCError(
+		CapabilityDisabled,
+		fmt.Sprintf("Capability disabled: %q is unavailable because the %q capability is disabled on this server", method, capability),
+		map[string]string{"method": method, "capability": capability},
+	)
+}
+
+// NewResourceNotFoundError creates the RPCError for an unknown resource URI.
+func NewResourceNotFoundError(uri string) *RPCError {
+	return NewRPCError(ResourceNotFound, "Resource not found", map[string]string{"uri": uri})
+}
+
+// NewReadOnlyModeError creates the RPCError for a call to a tool that is not read-only
+// while the server runs in read-only mode; FilterReadOnlyTools lists the tools that remain
+// callable. Like the capability errors, its data names the rejected method, plus the tool that was called.
+func NewReadOnlyModeError(tool string) *RPCError {
+	return NewRPCError(
+		ReadOnlyMode,
+		fmt.Sprintf("Read-only mode: tool %q is not available because it is not annotated as read-only", tool),
+		map[string]string{"method": MethodToolsCall, "tool": tool},
+	)
+}
@@ -1,8 +1,10 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"sync/atomic"
 )
 
@@ -15,16 +17,18 @@ type IDConstraint interface {
 }
 
 // ID is a generic type that can hold any type that satisfies the IDConstraint.
+//
+// IDs are comparable and can be used as map keys.
 type ID[T IDConstraint] struct {
 	Value T
 }
 
-// newID wraps a raw ID value (string or integer) into an IDType.
+// NewID wraps a raw ID value (string or integer) into an ID.
 //
-// This function is useful when you have an existing raw ID and want to create an IDType
+// This function is useful when you have an existing raw ID and want to create an ID
 // for use in requests or responses.
 //
-// Note: When using newID, it is your responsibility to ensure that the provided ID
+// Note: When using NewID, it is your responsibility to ensure that the provided ID
 // is unique within the same session, as required by the MCP protocol:
 // "The request ID MUST NOT have been previously used by the requestor within the same session."
 // See https://spec.modelcontextprotocol.io/specification/2025-03-26/basic/#requests
@@ -33,8 +37,8 @@ type ID[T IDConstraint] struct {
 //
 // Example:
 //
-//	id := protocol.newID(42)
-func newID[T IDConstraint](value T) ID[T] {
+//	id := protocol.NewID(42)
+func NewID[T IDConstraint](value T) ID[T] {
 	return ID[T]{Value: value}
 }
 
@@ -53,7 +57,7 @@ var idCounter int64
 //	id := protocol.NextIntID()
 func NextIntID() ID[int64] {
 	id := atomic.AddInt64(&idCounter, 1)
-	return newID(id)
+	return NewID(id)
 }
 
 // NextStringID generates a new unique string-based ID.
@@ -68,25 +72,76 @@ func NextIntID() ID[int64] {
 func NextStringID() ID[string] {
 
 	id := atomic.AddInt64(&idCounter, 1)
-	return newID(fmt.Sprintf("req-%d", id))
+	return NewID(fmt.Sprintf("req-%d", id))
 }
 
-// isEmpty checks if the IDType contains the zero value of its underlying type.
+// IsEmpty checks if the ID contains the zero value of its underlying type.
+//
+// Note that a zero numeric ID is rejected by validation unless its type is ZeroInt.
 //
 // Example:
 //
 //	id := protocol.NewID("")
-//	if id.isEmpty() {
+//	if id.IsEmpty() {
 //	    log.Println("ID is empty")
 //	}
-func (id ID[T]) isEmpty() bool {
+func (id ID[T]) IsEmpty() bool {
 	var zero T
 	return id.Value == zero
 }
 
+// ZeroInt is an integer ID value for peers that use 0 as a request ID.
+//
+// JSON-RPC treats 0 as a legal ID and some clients start counting from it, but
+// ID[int] and ID[int64] reject zero values as empty. Use ID[ZeroInt] where such
+// peers are expected: 0 then passes ID unmarshaling and request and response
+// validation. A missing or null ID is still an error.
+//
+// Example:
+//
+//	var req protocol.Request = protocol.NewRequest("ping", nil, protocol.NewID(protocol.ZeroInt(0)))
+type ZeroInt int64
+
+// isValid reports whether the ID is acceptable as a request or response ID.
+func (id ID[T]) isValid() bool {
+	return id.isValidAllowingZero(false)
+}
+
+// isValidAllowingZero is isValid with numeric zero also accepted when allowZero is set.
+// Empty string IDs are never valid.
+func (id ID[T]) isValidAllowingZero(allowZero bool) bool {
+	if !id.IsEmpty() {
+		return true
+	}
+	if _, ok := any(id.Value).(ZeroInt); ok {
+		return true
+	}
+	return allowZero && reflect.ValueOf(id.Value).Kind() != reflect.String
+}
+
+// String returns the underlying ID value formatted as text.
+//
+// Example:
+//
+//	fmt.Println(protocol.NewID(42)) // Output: 42
+func (id ID[T]) String() string {
+	return fmt.Sprint(id.Value)
+}
+
+// Equal reports whether two IDs hold the same value.
+//
+// Example:
+//
+//	if resp.ID.Equal(req.ID) {
+//	    // response matches the request
+//	}
+func (id ID[T]) Equal(other ID[T]) bool {
+	return id.Value == other.Value
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 //
-// It ensures that the IDType is serialized as its underlying primitive value
+// It ensures that the ID is serialized as its underlying primitive value
 // (string or integer), not as a nested object.
 //
 // Example output:
@@ -98,20 +153,23 @@ func (id ID[T]) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 //
-// It parses the JSON value into the IDType and validates that it is not empty.
+// It parses the JSON value into the ID and validates that it is neither null nor empty.
 //
 // Example:
 //
-//	var id protocol.IDType[int]
+//	var id protocol.ID[int]
 //	err := json.Unmarshal([]byte("42"), &id)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 func (id *ID[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return ErrEmptyRequestID
+	}
 	if err := json.Unmarshal(data, &id.Value); err != nil {
 		return &InvalidIDError{Err: err}
 	}
-	if id.isEmpty() {
+	if !id.isValid() {
 		return ErrEmptyRequestID
 	}
 	return nil
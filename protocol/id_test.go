@@ -2,18 +2,19 @@ package protocol
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
 	"testing"
 )
 
 func TestNewIDCreatesCorrectValue(t *testing.T) {
-	IntegerID := newID(42)
+	IntegerID := NewID(42)
 	if IntegerID.Value != 42 {
 		t.Errorf("Expected 42, got %v", IntegerID.Value)
 	}
 
-	StringID := newID("custom")
+	StringID := NewID("custom")
 	if StringID.Value != "custom" {
 		t.Errorf("Expected 'custom', got %v", StringID.Value)
 	}
@@ -21,22 +22,22 @@ func TestNewIDCreatesCorrectValue(t *testing.T) {
 
 func TestIsEmptyDetectsZeroValues(t *testing.T) {
 	EmptyIntID := ID[int64]{}
-	if !EmptyIntID.isEmpty() {
+	if !EmptyIntID.IsEmpty() {
 		t.Errorf("Expected int64 zero to be empty")
 	}
 
 	NonEmptyIntID := ID[int64]{Value: 1}
-	if NonEmptyIntID.isEmpty() {
+	if NonEmptyIntID.IsEmpty() {
 		t.Errorf("Expected non-zero int64 to be not empty")
 	}
 
 	EmptyStringID := ID[string]{}
-	if !EmptyStringID.isEmpty() {
+	if !EmptyStringID.IsEmpty() {
 		t.Errorf("Expected empty string to be empty")
 	}
 
 	NonEmptyStringID := ID[string]{Value: "abc"}
-	if NonEmptyStringID.isEmpty() {
+	if NonEmptyStringID.IsEmpty() {
 		t.Errorf("Expected non-empty string to be not empty")
 	}
 }
@@ -170,7 +171,7 @@ func TestNextStringID(t *testing.T) {
 
 func TestMarshalJSON(t *testing.T) {
 	// ID с integer
-	intID := newID(42)
+	intID := NewID(42)
 	intData, err := intID.MarshalJSON()
 	if err != nil {
 		t.Fatalf("Error marshaling int ID: %v", err)
@@ -180,7 +181,7 @@ func TestMarshalJSON(t *testing.T) {
 	}
 
 	// ID со string
-	strID := newID("test-id")
+	strID := NewID("test-id")
 	strData, err := strID.MarshalJSON()
 	if err != nil {
 		t.Fatalf("Error marshaling string ID: %v", err)
@@ -194,7 +195,7 @@ func TestMarshalJSON(t *testing.T) {
 		ID ID[string] `json:"id"`
 	}
 
-	container := Container{ID: newID("serialized-id")}
+	container := Container{ID: NewID("serialized-id")}
 	containerData, err := json.Marshal(container)
 	if err != nil {
 		t.Fatalf("Error marshaling container: %v", err)
@@ -205,3 +206,64 @@ func TestMarshalJSON(t *testing.T) {
 		t.Errorf("Expected marshaled container to be '%s', got '%s'", expected, string(containerData))
 	}
 }
+
+func TestUnmarshalJSONRejectsNull(t *testing.T) {
+	t.Parallel()
+
+	var ZeroID ID[ZeroInt]
+	if Err := json.Unmarshal([]byte("null"), &ZeroID); Err != ErrEmptyRequestID {
+		t.Errorf("Expected ErrEmptyRequestID for null, got %v", Err)
+	}
+}
+
+func TestZeroIntPermitsNumericZero(t *testing.T) {
+	t.Parallel()
+
+	var ZeroID ID[ZeroInt]
+	if Err := json.Unmarshal([]byte("0"), &ZeroID); Err != nil {
+		t.Errorf("Expected zero ZeroInt ID to be accepted, got %v", Err)
+	}
+	if !ZeroID.IsEmpty() {
+		t.Error("Expected zero ZeroInt ID to still report IsEmpty")
+	}
+	if !ZeroID.isValid() {
+		t.Error("Expected zero ZeroInt ID to be valid")
+	}
+
+	var IntID ID[int64]
+	if Err := json.Unmarshal([]byte("0"), &IntID); Err != ErrEmptyRequestID {
+		t.Errorf("Expected zero int64 ID to stay rejected, got %v", Err)
+	}
+	if IntID.isValid() || !IntID.isValidAllowingZero(true) {
+		t.Error("Expected zero int64 ID to be valid only when zero is allowed")
+	}
+
+	var StrID ID[string]
+	if Err := json.Unmarshal([]byte(`""`), &StrID); Err != ErrEmptyRequestID {
+		t.Errorf("Expected empty string ID to stay rejected, got %v", Err)
+	}
+	if StrID.isValidAllowingZero(true) {
+		t.Error("Expected empty string ID to be invalid even when zero is allowed")
+	}
+}
+
+func TestIDString(t *testing.T) {
+	if S := NewID(42).String(); S != "42" {
+		t.Errorf("Expected '42', got %s", S)
+	}
+	if S := NewID("req-1").String(); S != "req-1" {
+		t.Errorf("Expected 'req-1', got %s", S)
+	}
+	if S := fmt.Sprintf("%v", NewID(int64(7))); S != "7" {
+		t.Errorf("Expected fmt to use String(), got %s", S)
+	}
+}
+
+func TestIDEqual(t *testing.T) {
+	if !NewID("a").Equal(NewID("a")) {
+		t.Error("Expected equal IDs to be Equal")
+	}
+	if NewID(1).Equal(NewID(2)) {
+		t.Error("Expected different IDs not to be Equal")
+	}
+}
@@ -0,0 +1,247 @@
+// Package idtest generates edge-case JSON encodings of request IDs and checks how
+// protocol.ID decodes them.
+//
+// It is meant for tests, both in this module and in integrations that accept IDs
+// from peers and want to be sure they handle huge numbers, floats, unicode strings
+// and null the same way the protocol package does.
+//
+// Example:
+//
+//	func TestIDs(t *testing.T) {
+//	    r := rand.New(rand.NewSource(1))
+//	    idtest.CheckRoundTrip[int64](t, idtest.Cases[int64](r, 100))
+//	}
+package idtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/marketconnect/mcp-go/protocol"
+)
+
+// Case is one JSON encoding of a request ID.
+type Case struct {
+	// Name describes the case in test output.
+	Name string
+	// JSON is the raw JSON value used as the ID.
+	JSON string
+	// Valid reports whether protocol.ID must accept the value.
+	Valid bool
+	// Zero marks a numeric zero, which only protocol.ID[protocol.ZeroInt] accepts.
+	// Valid is false for such cases.
+	Zero bool
+}
+
+// Request wraps the case into a JSON-RPC request with the given method.
+func (c Case) Request(method string) []byte {
+	return []byte(fmt.Sprintf(`{"jsonrpc":%q,"id":%s,"method":%q}`, protocol.JSONRPCVersion, c.JSON, method))
+}
+
+// Cases returns fixed edge cases for IDs of type T followed by n randomized valid
+// and invalid ones drawn from r. Pass a seeded r to get reproducible cases.
+func Cases[T protocol.IDConstraint](r *rand.Rand, n int) []Case {
+	if isString[T]() {
+		return append(stringEdgeCases(), randomStringCases(r, n)...)
+	}
+	return append(intEdgeCases(), randomIntCases(r, n)...)
+}
+
+// CheckRoundTrip decodes every case into a protocol.ID[T] and reports failures through t.
+//
+// Valid cases must decode to the same value encoding/json produces for T, and must
+// survive a marshal/unmarshal round trip. Invalid cases must fail with an error
+// matching protocol.ErrEmptyRequestID or protocol.ErrInvalidID. Zero cases must be
+// accepted when T is protocol.ZeroInt and rejected as empty otherwise.
+func CheckRoundTrip[T protocol.IDConstraint](t testing.TB, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		if err := checkCase[T](c); err != nil {
+			t.Errorf("%s (%s): %v", c.Name, c.JSON, err)
+		}
+	}
+}
+
+// checkCase implements CheckRoundTrip for a single case.
+func checkCase[T protocol.IDConstraint](c Case) error {
+	var id protocol.ID[T]
+	err := json.Unmarshal([]byte(c.JSON), &id)
+
+	if c.Zero {
+		if _, zeroAllowed := any(id.Value).(protocol.ZeroInt); !zeroAllowed {
+			if !errors.Is(err, protocol.ErrEmptyRequestID) {
+				return fmt.Errorf("zero ID not rejected as empty: %v", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("zero ID rejected: %v", err)
+		}
+		if !id.IsEmpty() {
+			return fmt.Errorf("zero ID decoded as %v", id)
+		}
+		return nil
+	}
+
+	if !c.Valid {
+		if err == nil {
+			return fmt.Errorf("invalid ID accepted as %v", id)
+		}
+		if !errors.Is(err, protocol.ErrEmptyRequestID) && !errors.Is(err, protocol.ErrInvalidID) {
+			return fmt.Errorf("invalid ID rejected with unexpected error: %v", err)
+		}
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("valid ID rejected: %v", err)
+	}
+	var expected T
+	if err := json.Unmarshal([]byte(c.JSON), &expected); err != nil {
+		return fmt.Errorf("decode expected value: %v", err)
+	}
+	if id.Value != expected {
+		return fmt.Errorf("decoded %v, expected %v", id.Value, expected)
+	}
+
+	data, err := json.Marshal(id)
+	if err != nil {
+		return fmt.Errorf("marshal: %v", err)
+	}
+	var again protocol.ID[T]
+	if err := json.Unmarshal(data, &again); err != nil {
+		return fmt.Errorf("round trip of %s rejected: %v", data, err)
+	}
+	if !again.Equal(id) {
+		return fmt.Errorf("round trip changed %v to %v", id, again)
+	}
+	return nil
+}
+
+// isString reports whether T is string-based.
+func isString[T protocol.IDConstraint]() bool {
+	var zero T
+	return reflect.ValueOf(zero).Kind() == reflect.String
+}
+
+// invalidForAll are encodings no ID type accepts.
+var invalidForAll = []Case{
+	{Name: "Null", JSON: `null`},
+	{Name: "True", JSON: `true`},
+	{Name: "Object", JSON: `{"id":1}`},
+	{Name: "Array", JSON: `[1]`},
+}
+
+func intEdgeCases() []Case {
+	cases := []Case{
+		{Name: "One", JSON: `1`, Valid: true},
+		{Name: "Negative", JSON: `-42`, Valid: true},
+		{Name: "MaxInt64", JSON: strconv.FormatInt(math.MaxInt64, 10), Valid: true},
+		{Name: "MinInt64", JSON: strconv.FormatInt(math.MinInt64, 10), Valid: true},
+		{Name: "Zero", JSON: `0`, Zero: true},
+		{Name: "NegativeZero", JSON: `-0`, Zero: true},
+		{Name: "Overflow", JSON: `9223372036854775808`},
+		{Name: "Underflow", JSON: `-9223372036854775809`},
+		{Name: "Huge", JSON: `123456789012345678901234567890`},
+		{Name: "Fraction", JSON: `1.5`},
+		{Name: "WholeFloat", JSON: `1.0`},
+		{Name: "Exponent", JSON: `1e3`},
+		{Name: "HugeExponent", JSON: `1e400`},
+		{Name: "NumericString", JSON: `"42"`},
+		{Name: "EmptyString", JSON: `""`},
+	}
+	return append(cases, invalidForAll...)
+}
+
+func stringEdgeCases() []Case {
+	cases := []Case{
+		{Name: "ASCII", JSON: `"req-1"`, Valid: true},
+		{Name: "Latin", JSON: `"héllo"`, Valid: true},
+		{Name: "CJK", JSON: `"请求-1"`, Valid: true},
+		{Name: "Emoji", JSON: `"🚀"`, Valid: true},
+		{Name: "EscapedUnicode", JSON: `"\u00e9"`, Valid: true},
+		{Name: "SurrogatePair", JSON: `"\ud83d\ude80"`, Valid: true},
+		{Name: "EscapedControl", JSON: `"a\nb\tc"`, Valid: true},
+		{Name: "EscapedNul", JSON: `"\u0000"`, Valid: true},
+		{Name: "QuotesAndBackslash", JSON: `"\"\\"`, Valid: true},
+		{Name: "NullWord", JSON: `"null"`, Valid: true},
+		{Name: "Whitespace", JSON: `" "`, Valid: true},
+		{Name: "Long", JSON: strconv.Quote(strings.Repeat("x", 4096)), Valid: true},
+		{Name: "EmptyString", JSON: `""`},
+		{Name: "Number", JSON: `42`},
+		{Name: "Float", JSON: `1.5`},
+	}
+	return append(cases, invalidForAll...)
+}
+
+func randomIntCases(r *rand.Rand, n int) []Case {
+	cases := make([]Case, 0, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("Random%d", i)
+		switch r.Intn(4) {
+		case 0, 1:
+			v := r.Int63()
+			if r.Intn(2) == 0 {
+				v = -v
+			}
+			cases = append(cases, Case{Name: name, JSON: strconv.FormatInt(v, 10), Valid: v != 0, Zero: v == 0})
+		case 2:
+			// The fraction stays within [0.25, 0.75), so the value is never whole.
+			v := float64(r.Int63n(1<<40)) + 0.25 + r.Float64()/2
+			cases = append(cases, Case{Name: name + "Float", JSON: strconv.FormatFloat(v, 'f', -1, 64)})
+		default:
+			cases = append(cases, Case{Name: name + "Huge", JSON: randomHugeNumber(r)})
+		}
+	}
+	return cases
+}
+
+// randomHugeNumber returns an integer literal outside the int64 range.
+func randomHugeNumber(r *rand.Rand) string {
+	var b strings.Builder
+	if r.Intn(2) == 0 {
+		b.WriteByte('-')
+	}
+	b.WriteByte(byte('1' + r.Intn(9)))
+	for i, digits := 0, 19+r.Intn(30); i < digits; i++ {
+		b.WriteByte(byte('0' + r.Intn(10)))
+	}
+	return b.String()
+}
+
+// runeRanges are the alphabets random string IDs are drawn from.
+var runeRanges = [][2]rune{
+	{0x20, 0x7e},       // printable ASCII
+	{0x00, 0x1f},       // control characters
+	{0xa0, 0x24f},      // Latin-1 and Latin Extended
+	{0x400, 0x4ff},     // Cyrillic
+	{0x4e00, 0x9fff},   // CJK
+	{0x1f300, 0x1faff}, // emoji
+}
+
+func randomStringCases(r *rand.Rand, n int) []Case {
+	cases := make([]Case, 0, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("Random%d", i)
+		if r.Intn(4) == 0 {
+			cases = append(cases, Case{Name: name + "Number", JSON: strconv.FormatInt(r.Int63(), 10)})
+			continue
+		}
+
+		runes := make([]rune, 1+r.Intn(32))
+		for j := range runes {
+			rng := runeRanges[r.Intn(len(runeRanges))]
+			runes[j] = rng[0] + rune(r.Int63n(int64(rng[1]-rng[0]+1)))
+		}
+		data, _ := json.Marshal(string(runes))
+		cases = append(cases, Case{Name: name, JSON: string(data), Valid: true})
+	}
+	return cases
+}
@@ -0,0 +1,80 @@
+package idtest
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/marketconnect/mcp-go/protocol"
+)
+
+func TestCheckRoundTripInt64(t *testing.T) {
+	CheckRoundTrip[int64](t, Cases[int64](rand.New(rand.NewSource(1)), 500))
+}
+
+func TestCheckRoundTripInt(t *testing.T) {
+	CheckRoundTrip[int](t, Cases[int](rand.New(rand.NewSource(2)), 500))
+}
+
+func TestCheckRoundTripString(t *testing.T) {
+	CheckRoundTrip[string](t, Cases[string](rand.New(rand.NewSource(3)), 500))
+}
+
+func TestCheckRoundTripZeroInt(t *testing.T) {
+	CheckRoundTrip[protocol.ZeroInt](t, Cases[protocol.ZeroInt](rand.New(rand.NewSource(4)), 500))
+}
+
+func TestCasesAreReproducibleAndMixed(t *testing.T) {
+	first := Cases[int64](rand.New(rand.NewSource(7)), 200)
+	second := Cases[int64](rand.New(rand.NewSource(7)), 200)
+	if len(first) != len(second) {
+		t.Fatalf("Expected same number of cases, got %d and %d", len(first), len(second))
+	}
+
+	valid, invalid := 0, 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Case %d differs for the same seed: %+v vs %+v", i, first[i], second[i])
+		}
+		if first[i].Valid {
+			valid++
+		} else {
+			invalid++
+		}
+	}
+	if valid == 0 || invalid == 0 {
+		t.Errorf("Expected both valid and invalid cases, got %d valid and %d invalid", valid, invalid)
+	}
+}
+
+func TestCheckCaseDetectsMismatches(t *testing.T) {
+	tests := []Case{
+		{Name: "InvalidMarkedValid", JSON: `1.5`, Valid: true},
+		{Name: "ValidMarkedInvalid", JSON: `7`},
+		{Name: "NonZeroMarkedZero", JSON: `7`, Zero: true},
+	}
+	for _, c := range tests {
+		if err := checkCase[int64](c); err == nil {
+			t.Errorf("%s: expected a failure", c.Name)
+		}
+	}
+	if err := checkCase[protocol.ZeroInt](Case{Name: "NonZeroMarkedZero", JSON: `7`, Zero: true}); err == nil {
+		t.Error("Expected a failure for a non-zero value marked zero")
+	}
+}
+
+func TestCaseRequest(t *testing.T) {
+	data := Case{JSON: `"é"`}.Request("ping")
+
+	var decoded struct {
+		JSONRPC string `json:"jsonrpc"`
+		ID      string `json:"id"`
+		Method  string `json:"method"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Request produced invalid JSON %s: %v", data, err)
+	}
+	if decoded.JSONRPC != protocol.JSONRPCVersion || decoded.ID != "é" || decoded.Method != "ping" {
+		t.Errorf("Unexpected request: %s", data)
+	}
+}
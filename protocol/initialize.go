@@ -0,0 +1,198 @@
+package protocol
+
+import "strings"
+
+// MethodInitialize is the method of the request that starts every MCP session.
+const MethodInitialize = "initialize"
+
+// Implementation describes the name and version of an MCP client or server implementation.
+//
+// Example:
+//
+//	{"name": "claude-desktop", "version": "1.2.0"}
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// String formats the implementation as "name/version", suitable for log fields and metric labels.
+func (i Implementation) String() string {
+	if i.Version == "" {
+		return i.Name
+	}
+	return i.Name + "/" + i.Version
+}
+
+// RootsCapability describes the client's support for filesystem roots.
+type RootsCapability struct {
+	// ListChanged indicates whether the client sends notifications when the list of roots changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ClientCapabilities are the optional features a client declares during initialization.
+type ClientCapabilities struct {
+	// Experimental holds non-standard capabilities keyed by name.
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	// Roots is present if the client can list filesystem roots.
+	Roots *RootsCapability `json:"roots,omitempty"`
+	// Sampling is present if the client supports sampling from an LLM.
+	Sampling *struct{} `json:"sampling,omitempty"`
+}
+
+// InitializeParams are the params of the initialize request sent by the client.
+type InitializeParams struct {
+	// ProtocolVersion is the latest protocol revision the client supports.
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      Implementation     `json:"clientInfo"`
+}
+
+// validate checks that the fields required by the specification are present.
+func (p InitializeParams) validate() error {
+	if strings.TrimSpace(p.ProtocolVersion) == "" {
+		return &ValidationError{Reason: "initialize: protocolVersion must not be empty"}
+	}
+	if strings.TrimSpace(p.ClientInfo.Name) == "" {
+		return &ValidationError{Reason: "initialize: clientInfo.name must not be empty"}
+	}
+	return nil
+}
+
+// ParseInitializeParams extracts and validates the params of an initialize request,
+// giving servers access to the client's declared protocol version, capabilities and clientInfo.
+//
+// Example:
+//
+//	params, err := protocol.ParseInitializeParams(req)
+//	if err != nil {
+//	    return protocol.NewRPCError(protocol.InvalidParams, err.Error(), nil)
+//	}
+//	log.Printf("client connected: %s", params.ClientInfo)
+func ParseInitializeParams(req Request) (*InitializeParams, error) {
+	if req.GetMethod() != MethodInitialize {
+		return nil, NewValidationError("expected %q request, got %q", MethodInitialize, req.GetMethod())
+	}
+	if req.GetParams() == nil {
+		return nil, &ValidationError{Reason: "initialize: params are required"}
+	}
+
+	var params InitializeParams
+	if err := DecodeParams(req.GetParams(), &params); err != nil {
+		return nil, err
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// ListChangedCapability describes support for list_changed notifications of a feature.
+type ListChangedCapability struct {
+	// ListChanged indicates whether the server sends notifications when the list changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ResourcesCapability describes the server's support for resources.
+type ResourcesCapability struct {
+	// Subscribe indicates whether clients can subscribe to resource updates.
+	Subscribe bool `json:"subscribe,omitempty"`
+	// ListChanged indicates whether the server sends notifications when the list of resources changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// Server capability names accepted by ServerCapabilities.Has and Require.
+const (
+	ServerCapabilityLogging            = "logging"
+	ServerCapabilityCompletions        = "completions"
+	ServerCapabilityPrompts            = "prompts"
+	ServerCapabilityResources          = "resources"
+	ServerCapabilityResourcesSubscribe = "resources.subscribe"
+	ServerCapabilityTools              = "tools"
+)
+
+// ServerCapabilities are the optional features a server declares during initialization.
+type ServerCapabilities struct {
+	// Experimental holds non-standard capabilities keyed by name.
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	// Logging is present if the server can send log messages to the client.
+	Logging *struct{} `json:"logging,omitempty"`
+	// Completions is present if the server supports argument autocompletion.
+	Completions *struct{} `json:"completions,omitempty"`
+	// Prompts is present if the server offers prompt templates.
+	Prompts *ListChangedCapability `json:"prompts,omitempty"`
+	// Resources is present if the server offers resources.
+	Resources *ResourcesCapability `json:"resources,omitempty"`
+	// Tools is present if the server offers tools.
+	Tools *ListChangedCapability `json:"tools,omitempty"`
+}
+
+// Has reports whether the server declared the named capability.
+// Names other than the ServerCapability constants are looked up in Experimental.
+func (c ServerCapabilities) Has(name string) bool {
+	switch name {
+	case ServerCapabilityLogging:
+		return c.Logging != nil
+	case ServerCapabilityCompletions:
+		return c.Completions != nil
+	case ServerCapabilityPrompts:
+		return c.Prompts != nil
+	case ServerCapabilityResources:
+		return c.Resources != nil
+	case ServerCapabilityResourcesSubscribe:
+		return c.Resources != nil && c.Resources.Subscribe
+	case ServerCapabilityTools:
+		return c.Tools != nil
+	default:
+		_, ok := c.Experimental[name]
+		return ok
+	}
+}
+
+// Require checks that the server declared every named capability, so a host can fail
+// fast or degrade right after initialization instead of getting MethodNotFound later.
+//
+// Returns a MissingCapabilitiesError listing all missing capabilities.
+//
+// Example:
+//
+//	if err := result.Capabilities.Require(protocol.ServerCapabilityTools, protocol.ServerCapabilityResourcesSubscribe); err != nil {
+//	    return fmt.Errorf("unsupported server: %w", err)
+//	}
+func (c ServerCapabilities) Require(names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if !c.Has(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingCapabilitiesError{Missing: missing}
+	}
+	return nil
+}
+
+// InitializeResult is the result the server returns for the initialize request.
+type InitializeResult struct {
+	// ProtocolVersion is the protocol revision the server wants to use.
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+
+	// Instructions describe how to use the server and its features.
+	// Clients may add them to the system prompt to improve the LLM's understanding of the server.
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// NewInitializeResult creates the result of an initialize request.
+//
+// Example:
+//
+//	result := protocol.NewInitializeResult("2025-03-26", caps, protocol.Implementation{Name: "my-server", Version: "1.0.0"})
+//	result.Instructions = "Use the search tool before reading files."
+func NewInitializeResult(protocolVersion string, capabilities ServerCapabilities, serverInfo Implementation) *InitializeResult {
+	return &InitializeResult{
+		ProtocolVersion: protocolVersion,
+		Capabilities:    capabilities,
+		ServerInfo:      serverInfo,
+	}
+}
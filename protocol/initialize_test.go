@@ -0,0 +1,151 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestImplementationString(t *testing.T) {
+	if s := (Implementation{Name: "client", Version: "1.0.0"}).String(); s != "client/1.0.0" {
+		t.Errorf("Expected 'client/1.0.0', got %s", s)
+	}
+	if s := (Implementation{Name: "client"}).String(); s != "client" {
+		t.Errorf("Expected 'client', got %s", s)
+	}
+}
+
+func TestParseInitializeParamsFromDecodedRequest(t *testing.T) {
+	data := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{
+		"protocolVersion":"2025-03-26",
+		"capabilities":{"roots":{"listChanged":true},"sampling":{}},
+		"clientInfo":{"name":"example-client","version":"0.3.1"}}}`
+
+	var req jsonRPCRequest[int]
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	params, err := ParseInitializeParams(&req)
+	if err != nil {
+		t.Fatalf("ParseInitializeParams failed: %v", err)
+	}
+	if params.ProtocolVersion != "2025-03-26" {
+		t.Errorf("Unexpected protocol version: %s", params.ProtocolVersion)
+	}
+	if params.ClientInfo != (Implementation{Name: "example-client", Version: "0.3.1"}) {
+		t.Errorf("Unexpected client info: %+v", params.ClientInfo)
+	}
+	if params.Capabilities.Roots == nil || !params.Capabilities.Roots.ListChanged {
+		t.Error("Expected roots.listChanged capability")
+	}
+	if params.Capabilities.Sampling == nil {
+		t.Error("Expected sampling capability")
+	}
+}
+
+func TestParseInitializeParamsFromStruct(t *testing.T) {
+	req := NewRequest(MethodInitialize, InitializeParams{
+		ProtocolVersion: "2025-03-26",
+		ClientInfo:      Implementation{Name: "typed"},
+	}, NewID("init"))
+
+	params, err := ParseInitializeParams(req)
+	if err != nil {
+		t.Fatalf("ParseInitializeParams failed: %v", err)
+	}
+	if params.ClientInfo.Name != "typed" {
+		t.Errorf("Unexpected client info: %+v", params.ClientInfo)
+	}
+}
+
+func TestParseInitializeParamsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  Request
+	}{
+		{"WrongMethod", NewRequest("ping", nil, NewID("1"))},
+		{"NoParams", NewRequest(MethodInitialize, nil, NewID("2"))},
+		{"BadParams", NewRequest(MethodInitialize, json.RawMessage(`{"clientInfo":"x"}`), NewID("3"))},
+		{"NoVersion", NewRequest(MethodInitialize, map[string]any{"clientInfo": map[string]any{"name": "c"}}, NewID("4"))},
+		{"NoClientName", NewRequest(MethodInitialize, map[string]any{"protocolVersion": "2025-03-26"}, NewID("5"))},
+	}
+
+	for _, test := range tests {
+		var vErr *ValidationError
+		if _, err := ParseInitializeParams(test.req); !errors.As(err, &vErr) {
+			t.Errorf("%s: expected ValidationError, got %v", test.name, err)
+		}
+	}
+}
+
+func TestInitializeResultMarshal(t *testing.T) {
+	result := NewInitializeResult("2025-03-26", ServerCapabilities{
+		Tools:     &ListChangedCapability{ListChanged: true},
+		Resources: &ResourcesCapability{Subscribe: true},
+		Logging:   &struct{}{},
+	}, Implementation{Name: "server", Version: "1.0.0"})
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"protocolVersion":"2025-03-26","capabilities":{"logging":{},"resources":{"subscribe":true},"tools":{"listChanged":true}},"serverInfo":{"name":"server","version":"1.0.0"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON: %s, got: %s", expected, data)
+	}
+
+	result.Instructions = "Call search before read."
+	data, err = json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if decoded["instructions"] != "Call search before read." {
+		t.Errorf("Expected instructions in JSON, got %s", data)
+	}
+}
+
+func TestServerCapabilitiesRequire(t *testing.T) {
+	caps := ServerCapabilities{
+		Tools:        &ListChangedCapability{},
+		Resources:    &ResourcesCapability{},
+		Experimental: map[string]interface{}{"x-batch": map[string]interface{}{}},
+	}
+
+	if err := caps.Require(ServerCapabilityTools, ServerCapabilityResources, "x-batch"); err != nil {
+		t.Errorf("Expected declared capabilities to pass, got %v", err)
+	}
+
+	err := caps.Require(ServerCapabilityTools, ServerCapabilityPrompts, ServerCapabilityResourcesSubscribe, "x-other")
+	var capErr *MissingCapabilitiesError
+	if !errors.As(err, &capErr) || !errors.Is(err, ErrMissingCapability) {
+		t.Fatalf("Expected MissingCapabilitiesError, got %v", err)
+	}
+	expected := []string{ServerCapabilityPrompts, ServerCapabilityResourcesSubscribe, "x-other"}
+	if !reflect.DeepEqual(capErr.Missing, expected) {
+		t.Errorf("Expected missing %v, got %v", expected, capErr.Missing)
+	}
+	if err.Error() != "required capability not declared: prompts, resources.subscribe, x-other" {
+		t.Errorf("Unexpected message: %s", err)
+	}
+}
+
+func TestServerCapabilitiesHas(t *testing.T) {
+	caps := ServerCapabilities{
+		Logging:     &struct{}{},
+		Completions: &struct{}{},
+		Resources:   &ResourcesCapability{Subscribe: true},
+	}
+
+	for _, name := range []string{ServerCapabilityLogging, ServerCapabilityCompletions, ServerCapabilityResources, ServerCapabilityResourcesSubscribe} {
+		if !caps.Has(name) {
+			t.Errorf("Expected %s to be declared", name)
+		}
+	}
+	if caps.Has(ServerCapabilityTools) || caps.Has("unknown") {
+		t.Error("Expected undeclared capabilities to be missing")
+	}
+}
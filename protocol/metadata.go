@@ -0,0 +1,41 @@
+package protocol
+
+// Role identifies the sender or intended recipient of a message or piece of content.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Icon is an image that clients can display next to a tool, prompt or resource.
+//
+// Example:
+//
+//	{"src": "https://example.com/search.png", "mimeType": "image/png", "sizes": ["48x48"]}
+type Icon struct {
+	// Src is a URI (https: or data:) pointing to the icon image.
+	Src string `json:"src"`
+	// MimeType optionally overrides the MIME type inferred from Src.
+	MimeType string `json:"mimeType,omitempty"`
+	// Sizes optionally lists the sizes the icon is available in, e.g. "48x48" or "any".
+	Sizes []string `json:"sizes,omitempty"`
+}
+
+// Annotations give clients hints about how to use or display an object.
+type Annotations struct {
+	// Audience describes who the object is intended for.
+	Audience []Role `json:"audience,omitempty"`
+	// Priority is the importance of the object from 0 (least) to 1 (most).
+	Priority *float64 `json:"priority,omitempty"`
+	// LastModified is the ISO 8601 time the object was last modified.
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// displayName returns title when set, falling back to name.
+func displayName(title, name string) string {
+	if title != "" {
+		return title
+	}
+	return name
+}
@@ -0,0 +1,152 @@
+package protocol
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMetadataOmittedWhenUnset(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"Tool", Tool{Name: "search", InputSchema: map[string]string{"type": "object"}}, `{"name":"search","inputSchema":{"type":"object"}}`},
+		{"Prompt", Prompt{Name: "review"}, `{"name":"review"}`},
+		{"Resource", Resource{URI: "file:///a.txt", Name: "a.txt"}, `{"uri":"file:///a.txt","name":"a.txt"}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", test.name, err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("%s: expected JSON %s, got %s", test.name, test.expected, data)
+		}
+	}
+}
+
+func TestMetadataTitleAndIcons(t *testing.T) {
+	priority := 0.0
+	icons := []Icon{{Src: "https://example.com/i.png", MimeType: "image/png", Sizes: []string{"48x48"}}}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			"Tool",
+			Tool{Name: "search", Title: "Search", InputSchema: map[string]string{"type": "object"}, Icons: icons},
+			`{"name":"search","title":"Search","inputSchema":{"type":"object"},"icons":[{"src":"https://example.com/i.png","mimeType":"image/png","sizes":["48x48"]}]}`,
+		},
+		{
+			"Prompt",
+			Prompt{Name: "review", Title: "Code review", Arguments: []PromptArgument{{Name: "code", Title: "Code", Required: true}}},
+			`{"name":"review","title":"Code review","arguments":[{"name":"code","title":"Code","required":true}]}`,
+		},
+		{
+			"Resource",
+			Resource{URI: "file:///a.txt", Name: "a.txt", Title: "A", Annotations: &Annotations{Audience: []Role{RoleUser}, Priority: &priority}},
+			`{"uri":"file:///a.txt","name":"a.txt","title":"A","annotations":{"audience":["user"],"priority":0}}`,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.value)
+		if err != nil {
+			t.Fatalf("%s: marshal failed: %v", test.name, err)
+		}
+		if string(data) != test.expected {
+			t.Errorf("%s: expected JSON %s, got %s", test.name, test.expected, data)
+		}
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	if name := (Tool{Name: "search"}).DisplayName(); name != "search" {
+		t.Errorf("Expected 'search', got %s", name)
+	}
+	if name := (Tool{Name: "search", Annotations: &ToolAnnotations{Title: "Annotated"}}).DisplayName(); name != "Annotated" {
+		t.Errorf("Expected 'Annotated', got %s", name)
+	}
+	if name := (Tool{Name: "search", Title: "Search", Annotations: &ToolAnnotations{Title: "Annotated"}}).DisplayName(); name != "Search" {
+		t.Errorf("Expected 'Search', got %s", name)
+	}
+	if name := (Prompt{Name: "review", Title: "Review"}).DisplayName(); name != "Review" {
+		t.Errorf("Expected 'Review', got %s", name)
+	}
+	if name := (Resource{Name: "a.txt"}).DisplayName(); name != "a.txt" {
+		t.Errorf("Expected 'a.txt', got %s", name)
+	}
+}
+
+func TestListResultsMarshal(t *testing.T) {
+	data, err := json.Marshal(ListToolsResult{Tools: []Tool{}, NextCursor: "next"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"tools":[],"nextCursor":"next"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	data, _ = json.Marshal(ListPromptsResult{Prompts: []Prompt{{Name: "p"}}})
+	if string(data) != `{"prompts":[{"name":"p"}]}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	data, _ = json.Marshal(ListResourcesResult{Resources: []Resource{}})
+	if string(data) != `{"resources":[]}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
+
+func TestResourceSizeAndLastModified(t *testing.T) {
+	size := int64(1024)
+	res := Resource{URI: "file:///a.txt", Name: "a.txt", Size: &size}
+	res.SetLastModified(time.Date(2025, 1, 12, 15, 0, 58, 0, time.FixedZone("UTC+3", 3*3600)))
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"uri":"file:///a.txt","name":"a.txt","size":1024,"annotations":{"lastModified":"2025-01-12T12:00:58Z"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+}
+
+func TestResourceSetFileInfo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+
+	var res Resource
+	res.SetFileInfo(info)
+	if res.Size == nil || *res.Size != 5 {
+		t.Errorf("Expected size 5, got %v", res.Size)
+	}
+	if res.Annotations == nil || res.Annotations.LastModified != info.ModTime().UTC().Format(time.RFC3339) {
+		t.Errorf("Unexpected lastModified: %+v", res.Annotations)
+	}
+
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	var dirRes Resource
+	dirRes.SetFileInfo(dirInfo)
+	if dirRes.Size != nil {
+		t.Errorf("Expected no size for directory, got %d", *dirRes.Size)
+	}
+}
@@ -0,0 +1,57 @@
+package protocol
+
+// PromptArgument describes an argument a prompt template accepts.
+type PromptArgument struct {
+	Name string `json:"name"`
+	// Title is an optional human-friendly name for display.
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Required indicates the argument must be provided.
+	Required bool `json:"required,omitempty"`
+}
+
+// Prompt is the definition of a prompt template, as returned by prompts/list.
+type Prompt struct {
+	// Name uniquely identifies the prompt.
+	Name string `json:"name"`
+	// Title is an optional human-friendly name for display.
+	Title       string           `json:"title,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+	// Icons are optional images clients can display for the prompt.
+	Icons []Icon `json:"icons,omitempty"`
+}
+
+// DisplayName returns Title if set, otherwise Name.
+func (p Prompt) DisplayName() string {
+	return displayName(p.Title, p.Name)
+}
+
+// ListPromptsResult is the result of a prompts/list request.
+type ListPromptsResult struct {
+	Prompts []Prompt `json:"prompts"`
+	// NextCursor is set when more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// PromptMessage is a single message of a prompt returned by prompts/get.
+type PromptMessage struct {
+	Role    Role    `json:"role"`
+	Content Content `json:"content"`
+}
+
+// UnmarshalJSON decodes the message, choosing the content block type from its "type" field.
+func (m *PromptMessage) UnmarshalJSON(data []byte) error {
+	role, content, err := unmarshalMessage(data)
+	if err != nil {
+		return err
+	}
+	*m = PromptMessage{Role: role, Content: content}
+	return nil
+}
+
+// GetPromptResult is the result of a prompts/get request.
+type GetPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
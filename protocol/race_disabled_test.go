@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package protocol
+
+// raceEnabled reports whether tests run with the race detector, which adds allocations.
+const raceEnabled = false
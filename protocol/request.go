@@ -28,20 +28,29 @@ func (r jsonRPCRequest[T]) validate() error {
 		return &ValidationError{Reason: fmt.Sprintf("invalid JSON-RPC version: expected %q, got %q", JSONRPCVersion, r.JSONRPC)}
 	}
 
-	if len(strings.TrimSpace(r.Method)) == 0 {
-		return &ValidationError{Reason: "method name cannot be empty or whitespace"}
+	if err := validateRequestMethod(r.Method); err != nil {
+		return err
 	}
 
-	if r.ID.isEmpty() {
+	if !r.ID.isValid() {
 		return &ValidationError{Reason: "id must not be empty"}
 	}
 
+	return nil
+}
+
+// validateRequestMethod checks that method is non-empty and not reserved.
+func validateRequestMethod(method string) error {
+	if len(strings.TrimSpace(method)) == 0 {
+		return &ValidationError{Reason: "method name cannot be empty or whitespace"}
+	}
+
 	// https://www.jsonrpc.org/specification#request_object
 	// Method names that begin with the word rpc followed by
 	// a period character (U+002E or ASCII 46) are reserved
 	// for rpc-internal methods and extensions and MUST NOT be used for anything else.
-	if strings.HasPrefix(r.Method, "rpc.") {
-		return &ValidationError{Reason: fmt.Sprintf("method names starting with 'rpc.' are reserved, got: %q", r.Method)}
+	if strings.HasPrefix(method, "rpc.") {
+		return &ValidationError{Reason: fmt.Sprintf("method names starting with 'rpc.' are reserved, got: %q", method)}
 	}
 
 	return nil
@@ -69,11 +78,17 @@ func (r *jsonRPCRequest[T]) UnmarshalJSON(data []byte) error {
 	type requestNoMethods jsonRPCRequest[T]
 	aux := &struct {
 		requestNoMethods
+		// ID shadows the embedded field so that a missing ID can be told apart from a zero one.
+		ID *ID[T] `json:"id"`
 	}{}
 	if err := json.Unmarshal(data, &aux); err != nil {
 		return err
 	}
+	if aux.ID == nil {
+		return &ValidationError{Reason: "id must not be empty"}
+	}
 	temp := jsonRPCRequest[T](aux.requestNoMethods)
+	temp.ID = *aux.ID
 	if err := temp.validate(); err != nil {
 		return err
 	}
@@ -126,6 +141,36 @@ func NewRequest[T IDConstraint](method string, params interface{}, id ID[T]) Req
 	}
 }
 
+// DecodeParams converts untyped message params into v.
+//
+// Params of received messages are generic JSON values (maps, slices, json.RawMessage),
+// while params of locally built messages may already be structs. DecodeParams handles
+// both by round-tripping through JSON. Nil params leave v untouched.
+//
+// Example:
+//
+//	var params protocol.InitializeParams
+//	if err := protocol.DecodeParams(req.GetParams(), &params); err != nil {
+//	    return protocol.NewRPCError(protocol.InvalidParams, err.Error(), nil)
+//	}
+func DecodeParams(params interface{}, v interface{}) error {
+	if params == nil {
+		return nil
+	}
+
+	data, ok := params.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return NewValidationError("invalid params: %v", err)
+	}
+	return nil
+}
+
 type Request interface {
 	GetID() any
 	SetID(any) error
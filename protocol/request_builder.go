@@ -0,0 +1,134 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// requestIDKind selects which generator the RequestBuilder uses for the request ID.
+type requestIDKind int
+
+const (
+	intRequestID requestIDKind = iota
+	stringRequestID
+)
+
+// RequestBuilder assembles validated JSON-RPC requests step by step.
+//
+// A fresh unique ID is generated on every call to Build, so a builder can be reused
+// as a template for several requests.
+//
+// Example:
+//
+//	req, err := protocol.NewRequestBuilder(protocol.MethodToolsCall).
+//	    Params(map[string]any{"name": "search", "arguments": args}).
+//	    Meta("progress-1").
+//	    StringID().
+//	    Build()
+type RequestBuilder struct {
+	method        string
+	params        interface{}
+	progressToken interface{}
+	idKind        requestIDKind
+}
+
+// NewRequestBuilder starts building a request for the given method.
+// By default the request gets an integer ID from NextIntID.
+func NewRequestBuilder(method string) *RequestBuilder {
+	return &RequestBuilder{method: method}
+}
+
+// Params sets the request parameters. Structs are marshaled as-is by encoding/json.
+func (b *RequestBuilder) Params(params interface{}) *RequestBuilder {
+	b.params = params
+	return b
+}
+
+// Meta sets the progress token sent in the "_meta" field of the params,
+// asking the receiver to report progress for this request.
+// The token must be a string or an integer.
+func (b *RequestBuilder) Meta(progressToken interface{}) *RequestBuilder {
+	b.progressToken = progressToken
+	return b
+}
+
+// IntID makes Build assign IDs generated by NextIntID.
+func (b *RequestBuilder) IntID() *RequestBuilder {
+	b.idKind = intRequestID
+	return b
+}
+
+// StringID makes Build assign IDs generated by NextStringID.
+func (b *RequestBuilder) StringID() *RequestBuilder {
+	b.idKind = stringRequestID
+	return b
+}
+
+// Build validates the request and creates it.
+// An ID is only allocated once validation has passed.
+//
+// Returns a ValidationError if the method is invalid, the progress token has an
+// unsupported type, or the params cannot carry "_meta" because they are not a JSON object.
+func (b *RequestBuilder) Build() (Request, error) {
+	if err := validateRequestMethod(b.method); err != nil {
+		return nil, err
+	}
+
+	params := b.params
+	if b.progressToken != nil {
+		var err error
+		if params, err = injectProgressToken(params, b.progressToken); err != nil {
+			return nil, err
+		}
+	}
+
+	switch b.idKind {
+	case stringRequestID:
+		return &jsonRPCRequest[string]{JSONRPC: JSONRPCVersion, Method: b.method, Params: params, ID: NextStringID()}, nil
+	default:
+		return &jsonRPCRequest[int64]{JSONRPC: JSONRPCVersion, Method: b.method, Params: params, ID: NextIntID()}, nil
+	}
+}
+
+// injectProgressToken marshals params to a JSON object and merges
+// {"_meta": {"progressToken": token}} into it.
+func injectProgressToken(params interface{}, token interface{}) (map[string]json.RawMessage, error) {
+	switch token.(type) {
+	case string, int, int32, int64, uint, uint32, uint64:
+	default:
+		return nil, NewValidationError("progress token must be a string or integer, got %T", token)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("marshal params: %w", err)
+		}
+		if string(data) != "null" {
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, NewValidationError("params must be a JSON object to carry _meta, got %s", data)
+			}
+		}
+	}
+
+	meta := make(map[string]json.RawMessage)
+	if existing, ok := fields["_meta"]; ok {
+		if err := json.Unmarshal(existing, &meta); err != nil {
+			return nil, NewValidationError("params _meta must be a JSON object, got %s", existing)
+		}
+	}
+
+	encodedToken, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("marshal progress token: %w", err)
+	}
+	meta["progressToken"] = encodedToken
+
+	encodedMeta, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("marshal _meta: %w", err)
+	}
+	fields["_meta"] = encodedMeta
+	return fields, nil
+}
@@ -0,0 +1,138 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRequestBuilderDefaultsToIntID(t *testing.T) {
+	Req, Err := NewRequestBuilder("ping").Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+	if _, Ok := Req.GetID().(int64); !Ok {
+		t.Errorf("Expected int64 ID, got %T", Req.GetID())
+	}
+	if Req.GetMethod() != "ping" || Req.GetParams() != nil {
+		t.Errorf("Unexpected request: %+v", Req)
+	}
+}
+
+func TestRequestBuilderStringIDIsUniquePerBuild(t *testing.T) {
+	Builder := NewRequestBuilder("tools/list").StringID()
+
+	First, Err := Builder.Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+	Second, Err := Builder.Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+
+	FirstID, Ok := First.GetID().(string)
+	if !Ok || !strings.HasPrefix(FirstID, "req-") {
+		t.Errorf("Expected string ID with 'req-' prefix, got %v", First.GetID())
+	}
+	if First.GetID() == Second.GetID() {
+		t.Errorf("Expected unique IDs, got %v twice", First.GetID())
+	}
+
+	if _, Err := Builder.IntID().Build(); Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+}
+
+func TestRequestBuilderInjectsProgressToken(t *testing.T) {
+	type CallParams struct {
+		Name string `json:"name"`
+	}
+
+	Req, Err := NewRequestBuilder("tools/call").
+		Params(CallParams{Name: "search"}).
+		Meta("progress-1").
+		Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+
+	Data, Err := json.Marshal(Req.GetParams())
+	if Err != nil {
+		t.Fatalf("Marshal failed: %v", Err)
+	}
+	Expected := `{"_meta":{"progressToken":"progress-1"},"name":"search"}`
+	if string(Data) != Expected {
+		t.Errorf("Expected params %s, got %s", Expected, Data)
+	}
+}
+
+func TestRequestBuilderMergesExistingMeta(t *testing.T) {
+	Req, Err := NewRequestBuilder("tools/call").
+		Params(map[string]any{"_meta": map[string]any{"trace": "abc"}}).
+		Meta(7).
+		Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+
+	Data, _ := json.Marshal(Req.GetParams())
+	Expected := `{"_meta":{"progressToken":7,"trace":"abc"}}`
+	if string(Data) != Expected {
+		t.Errorf("Expected params %s, got %s", Expected, Data)
+	}
+
+	Req, Err = NewRequestBuilder("ping").Meta(int64(8)).Build()
+	if Err != nil {
+		t.Fatalf("Build failed: %v", Err)
+	}
+	Data, _ = json.Marshal(Req.GetParams())
+	if string(Data) != `{"_meta":{"progressToken":8}}` {
+		t.Errorf("Unexpected params for nil input: %s", Data)
+	}
+}
+
+func TestRequestBuilderErrors(t *testing.T) {
+	Tests := []struct {
+		Name    string
+		Builder *RequestBuilder
+	}{
+		{"EmptyMethod", NewRequestBuilder(" ")},
+		{"ReservedMethod", NewRequestBuilder("rpc.discover")},
+		{"FloatToken", NewRequestBuilder("ping").Meta(1.5)},
+		{"NonObjectParams", NewRequestBuilder("ping").Params([]int{1}).Meta("t")},
+		{"NonObjectMeta", NewRequestBuilder("ping").Params(map[string]any{"_meta": 1}).Meta("t")},
+	}
+
+	for _, Test := range Tests {
+		var VErr *ValidationError
+		if _, Err := Test.Builder.Build(); !errors.As(Err, &VErr) {
+			t.Errorf("%s: expected ValidationError, got %v", Test.Name, Err)
+		}
+	}
+
+	if _, Err := NewRequestBuilder("ping").Params(make(chan int)).Meta("t").Build(); Err == nil {
+		t.Error("Expected marshal error for unsupported params")
+	}
+}
+
+func TestRequestBuilderFailedBuildKeepsID(t *testing.T) {
+	Before := atomic.LoadInt64(&idCounter)
+
+	for _, Builder := range []*RequestBuilder{
+		NewRequestBuilder(" "),
+		NewRequestBuilder("rpc.discover").StringID(),
+		NewRequestBuilder("ping").Meta(1.5),
+		NewRequestBuilder("ping").Params([]int{1}).Meta("t"),
+	} {
+		if _, Err := Builder.Build(); Err == nil {
+			t.Fatal("Expected build to fail")
+		}
+	}
+
+	if After := atomic.LoadInt64(&idCounter); After != Before {
+		t.Errorf("Expected failed builds to leave the ID counter at %d, got %d", Before, After)
+	}
+}
@@ -0,0 +1,88 @@
+package protocol
+
+import "iter"
+
+// CompleteAll stops tracking every request in ids under a single lock acquisition.
+// Unknown IDs are ignored. Returns the number of requests that were completed.
+func (m *RequestLifecycleManager[T]) CompleteAll(ids ...ID[T]) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	completed := 0
+	for _, id := range ids {
+		if m.removeRequest(id) {
+			completed++
+		}
+	}
+	return completed
+}
+
+// ResetTimeouts applies ResetTimeout to every request in ids under a single lock acquisition.
+// Unknown IDs are ignored. Returns the number of requests that were reset, which
+// excludes requests whose timeout has already fired but not yet been cleaned up.
+func (m *RequestLifecycleManager[T]) ResetTimeouts(ids ...ID[T]) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reset := 0
+	for _, id := range ids {
+		if state, exists := m.requests[id]; exists && m.resetTimeout(state) {
+			reset++
+		}
+	}
+	return reset
+}
+
+// StopWhere stops tracking every request whose ID matches predicate, without running
+// timeout callbacks, and returns the stopped IDs. Unlike StopAll, the manager keeps running.
+//
+// predicate is called with the manager locked and must not call back into the manager.
+// A cancellation notification is sent for each stopped request if WithCancellationNotifier is configured.
+//
+// Example:
+//
+//	stopped := manager.StopWhere(func(id protocol.ID[string]) bool {
+//	    return strings.HasPrefix(id.Value, "batch-7/")
+//	})
+func (m *RequestLifecycleManager[T]) StopWhere(predicate func(ID[T]) bool) []ID[T] {
+	m.mu.Lock()
+	var ids []ID[T]
+	for id := range m.requests {
+		if predicate(id) {
+			ids = append(ids, id)
+		}
+	}
+	for _, id := range ids {
+		m.removeRequest(id)
+	}
+	m.mu.Unlock()
+
+	for _, id := range ids {
+		m.notifyCancelled(id, "request stopped")
+	}
+	return ids
+}
+
+// All returns an iterator over the IDs of active requests that does not copy them
+// into a slice, unlike ActiveIDs.
+//
+// The manager is locked for the whole iteration, so the loop body must not call
+// back into the manager. Collect IDs and act on them afterwards, or use StopWhere.
+//
+// Example:
+//
+//	for id := range manager.All() {
+//	    fmt.Println(id)
+//	}
+func (m *RequestLifecycleManager[T]) All() iter.Seq[ID[T]] {
+	return func(yield func(ID[T]) bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		for id := range m.requests {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
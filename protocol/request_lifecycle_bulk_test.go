@@ -0,0 +1,117 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+)
+
+func startBulkRequests(t *testing.T, manager *RequestLifecycleManager[int64], n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		if err := manager.StartRequest(NewID(int64(i)), time.Second, time.Minute, func(ID[int64], TimeoutType) {}); err != nil {
+			t.Fatalf("StartRequest(%d) failed: %v", i, err)
+		}
+	}
+}
+
+func TestCompleteAll(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	startBulkRequests(t, manager, 4)
+
+	if completed := manager.CompleteAll(NewID(int64(1)), NewID(int64(3)), NewID(int64(99))); completed != 2 {
+		t.Errorf("Expected 2 completed, got %d", completed)
+	}
+	if manager.Len() != 2 {
+		t.Errorf("Expected 2 active requests, got %d", manager.Len())
+	}
+	if clock.Pending() != 4 {
+		t.Errorf("Expected timers of completed requests to be stopped, got %d pending", clock.Pending())
+	}
+	manager.StopAll(true)
+}
+
+func TestResetTimeouts(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	startBulkRequests(t, manager, 3)
+
+	clock.Advance(900 * time.Millisecond)
+	if reset := manager.ResetTimeouts(NewID(int64(1)), NewID(int64(2)), NewID(int64(99))); reset != 2 {
+		t.Errorf("Expected 2 reset, got %d", reset)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	ids := manager.ActiveIDs()
+	if len(ids) != 2 {
+		t.Fatalf("Expected only the request that was not reset to time out, got active %v", ids)
+	}
+}
+
+func TestResetTimeoutsSkipsFiredTimers(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	startBulkRequests(t, manager, 2)
+	if err := manager.StartIdleRequest(NewID(int64(3)), time.Second, time.Minute, func(ID[int64], TimeoutType) {}); err != nil {
+		t.Fatalf("StartIdleRequest failed: %v", err)
+	}
+
+	// Stopping the timers by hand leaves the requests in the state they are in after
+	// their timer has fired but before the callback has cleaned them up.
+	manager.requests[NewID(int64(1))].softTimer.Stop()
+	manager.requests[NewID(int64(3))].idleTimer.Stop()
+
+	if reset := manager.ResetTimeouts(NewID(int64(1)), NewID(int64(2)), NewID(int64(3))); reset != 1 {
+		t.Errorf("Expected only the request with a pending timer to be reset, got %d", reset)
+	}
+}
+
+func TestStopWhere(t *testing.T) {
+	var sent []Notification
+	manager := NewRequestLifecycleManager[int64](nil, WithCancellationNotifier[int64](func(n Notification) error {
+		sent = append(sent, n)
+		return nil
+	}))
+	startBulkRequests(t, manager, 5)
+
+	stopped := manager.StopWhere(func(id ID[int64]) bool { return id.Value%2 == 0 })
+	if len(stopped) != 2 {
+		t.Fatalf("Expected 2 stopped requests, got %v", stopped)
+	}
+	if manager.Len() != 3 || len(sent) != 2 {
+		t.Errorf("Expected 3 active and 2 cancellations, got %d and %d", manager.Len(), len(sent))
+	}
+	select {
+	case <-manager.Done():
+		t.Error("StopWhere must not stop the manager")
+	default:
+	}
+
+	manager.StopAll(true)
+}
+
+func TestAllIterator(t *testing.T) {
+	manager := NewRequestLifecycleManager[int64](nil)
+	startBulkRequests(t, manager, 3)
+	defer manager.StopAll(true)
+
+	seen := make(map[int64]bool)
+	for id := range manager.All() {
+		seen[id.Value] = true
+	}
+	if len(seen) != 3 || !seen[1] || !seen[2] || !seen[3] {
+		t.Errorf("Expected IDs 1-3, got %v", seen)
+	}
+
+	count := 0
+	for range manager.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected early break to stop iteration, got %d", count)
+	}
+	if manager.Len() != 3 {
+		t.Errorf("Expected manager to be usable after iteration, got %d", manager.Len())
+	}
+}
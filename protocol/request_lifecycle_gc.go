@@ -0,0 +1,86 @@
+package protocol
+
+import "time"
+
+// WithUsedIDTTL makes the manager forget the ID of a finished request once ttl has
+// passed since it completed, timed out or was stopped, so long-lived sessions do not
+// accumulate every ID they have ever seen. IDs of active requests are never forgotten.
+//
+// A forgotten ID can be started again, so choose a ttl well beyond the time a peer
+// could still send a duplicate. Expired IDs are purged by a background janitor that
+// runs every ttl on the manager's clock and stops with StopAll; PurgeExpiredIDs can
+// also be called directly.
+//
+// Example:
+//
+//	manager := protocol.NewRequestLifecycleManager[int64](ctx, protocol.WithUsedIDTTL[int64](time.Hour))
+func WithUsedIDTTL[T IDConstraint](ttl time.Duration) RequestLifecycleOption[T] {
+	return func(m *RequestLifecycleManager[T]) {
+		if ttl > 0 {
+			m.usedIDTTL = ttl
+			m.releasedAt = make(map[ID[T]]time.Time)
+		}
+	}
+}
+
+// PurgeExpiredIDs forgets the IDs of requests that finished at least the WithUsedIDTTL
+// duration ago and returns how many were removed. It does nothing without WithUsedIDTTL.
+func (m *RequestLifecycleManager[T]) PurgeExpiredIDs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.purgeExpiredIDs()
+}
+
+// ReclaimedIDs returns the total number of IDs forgotten since the manager was created.
+func (m *RequestLifecycleManager[T]) ReclaimedIDs() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.reclaimed
+}
+
+// purgeExpiredIDs implements PurgeExpiredIDs. The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) purgeExpiredIDs() int {
+	now := m.clock.Now()
+	purged := 0
+	for id, released := range m.releasedAt {
+		if now.Sub(released) < m.usedIDTTL {
+			continue
+		}
+		delete(m.releasedAt, id)
+		delete(m.usedIDs, id)
+		purged++
+	}
+	m.reclaimed += purged
+	return purged
+}
+
+// startJanitor schedules the periodic purge if WithUsedIDTTL is configured.
+func (m *RequestLifecycleManager[T]) startJanitor() {
+	if m.usedIDTTL <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.scheduleJanitor()
+}
+
+// scheduleJanitor arms the next purge. The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) scheduleJanitor() {
+	m.janitor = m.clock.AfterFunc(m.usedIDTTL, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		select {
+		case <-m.ctx.Done():
+			return
+		default:
+		}
+
+		m.purgeExpiredIDs()
+		m.scheduleJanitor()
+	})
+}
@@ -0,0 +1,112 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUsedIDTTLJanitorForgetsFinishedIDs(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithUsedIDTTL[int64](time.Minute),
+	)
+	callback := func(ID[int64], TimeoutType) {}
+
+	finished := NewID(int64(1))
+	active := NewID(int64(2))
+	manager.StartRequest(finished, time.Hour, 2*time.Hour, callback)
+	manager.StartRequest(active, time.Hour, 2*time.Hour, callback)
+	manager.CompleteRequest(finished)
+
+	clock.Advance(59 * time.Second)
+	if err := manager.StartRequest(finished, time.Hour, 2*time.Hour, callback); !errors.Is(err, ErrDuplicateRequestID) {
+		t.Fatalf("Expected ID to still be reserved, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if manager.ReclaimedIDs() != 1 {
+		t.Fatalf("Expected 1 reclaimed ID, got %d", manager.ReclaimedIDs())
+	}
+	if err := manager.StartRequest(finished, time.Hour, 2*time.Hour, callback); err != nil {
+		t.Errorf("Expected forgotten ID to be reusable, got %v", err)
+	}
+	if err := manager.StartRequest(active, time.Hour, 2*time.Hour, callback); !errors.Is(err, ErrDuplicateRequestID) {
+		t.Errorf("Expected active ID to stay reserved, got %v", err)
+	}
+
+	manager.StopAll(true)
+	pending := clock.Pending()
+	clock.Advance(time.Hour)
+	if pending != 0 || clock.Pending() != 0 {
+		t.Errorf("Expected janitor to stop with the manager, got %d pending timers", pending)
+	}
+}
+
+func TestRejectedStartDoesNotReserveID(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithUsedIDTTL[int64](time.Minute),
+	)
+	defer manager.StopAll(true)
+	callback := func(ID[int64], TimeoutType) {}
+
+	id := NewID(int64(1))
+	if err := manager.StartRequest(id, 2*time.Hour, time.Hour, callback); !errors.Is(err, ErrSoftTimeoutExceedsMaximum) {
+		t.Fatalf("Expected ErrSoftTimeoutExceedsMaximum, got %v", err)
+	}
+	if len(manager.usedIDs) != 0 {
+		t.Errorf("Expected rejected start to leave no used IDs, got %v", manager.usedIDs)
+	}
+
+	clock.Advance(time.Minute)
+	if err := manager.StartRequest(id, time.Hour, 2*time.Hour, callback); err != nil {
+		t.Errorf("Expected ID of rejected start to be usable, got %v", err)
+	}
+}
+
+func TestPurgeExpiredIDs(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithUsedIDTTL[int64](time.Hour),
+	)
+	defer manager.StopAll(true)
+
+	for i := int64(1); i <= 3; i++ {
+		manager.StartRequest(NewID(i), time.Hour, 2*time.Hour, func(ID[int64], TimeoutType) {})
+	}
+	manager.CompleteAll(NewID(int64(1)), NewID(int64(2)))
+
+	if purged := manager.PurgeExpiredIDs(); purged != 0 {
+		t.Errorf("Expected nothing to purge yet, got %d", purged)
+	}
+
+	clock.Advance(time.Hour - time.Second)
+	manager.CompleteRequest(NewID(int64(3)))
+	clock.Advance(time.Second)
+
+	// The janitor ran at the hour mark and collected the first two IDs.
+	if manager.ReclaimedIDs() != 2 {
+		t.Errorf("Expected 2 reclaimed IDs, got %d", manager.ReclaimedIDs())
+	}
+	if purged := manager.PurgeExpiredIDs(); purged != 0 {
+		t.Errorf("Expected the third ID to be kept until its own TTL, got %d", purged)
+	}
+}
+
+func TestPurgeExpiredIDsWithoutTTL(t *testing.T) {
+	manager := NewRequestLifecycleManager[int64](nil)
+	id := NewID(int64(1))
+	manager.StartRequest(id, time.Hour, 2*time.Hour, func(ID[int64], TimeoutType) {})
+	manager.CompleteRequest(id)
+
+	if purged := manager.PurgeExpiredIDs(); purged != 0 {
+		t.Errorf("Expected no purge without TTL, got %d", purged)
+	}
+	if manager.janitor != nil {
+		t.Error("Expected no janitor without TTL")
+	}
+}
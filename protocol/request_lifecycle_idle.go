@@ -0,0 +1,106 @@
+package protocol
+
+import "time"
+
+// StartIdleRequest begins tracking a request that times out after a period of inactivity.
+//
+// The idle timer restarts on every call to Touch, so a request that keeps reporting
+// progress stays alive, while maximumTimeout still bounds its total duration as the
+// MCP specification requires. Whichever fires first cleans the request up and calls
+// onTimeout with IdleTimeout or MaximumTimeout.
+//
+// Returns an error if:
+//   - The request ID has already been used in this session.
+//   - The timeouts are not positive, or idleTimeout is not shorter than maximumTimeout.
+//
+// Example:
+//
+//	manager.StartIdleRequest(id, 10*time.Second, 5*time.Minute, onTimeout)
+//	// on every notifications/progress for the request:
+//	manager.Touch(id)
+func (m *RequestLifecycleManager[T]) StartIdleRequest(
+	id ID[T],
+	idleTimeout time.Duration,
+	maximumTimeout time.Duration,
+	onTimeout func(ID[T], TimeoutType),
+) error {
+	if onTimeout == nil {
+		return ErrCallbackNil
+	}
+	if !m.validID(id) {
+		return ErrEmptyRequestID
+	}
+	if idleTimeout <= 0 {
+		return ErrIdleTimeoutNotPositive
+	}
+	if maximumTimeout <= 0 {
+		return ErrMaximumTimeoutNotPositive
+	}
+	if idleTimeout >= maximumTimeout {
+		return ErrIdleTimeoutExceedsMaximum
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, used := m.usedIDs[id]; used {
+		return ErrDuplicateRequestID
+	}
+	m.usedIDs[id] = struct{}{}
+
+	state := &requestState[T]{
+		id:             id,
+		idleTimeout:    idleTimeout,
+		maximumTimeout: maximumTimeout,
+		lastActivity:   m.clock.Now(),
+		onTimeout:      onTimeout,
+	}
+
+	m.wg.Add(1)
+
+	state.idleTimer = m.clock.AfterFunc(idleTimeout, func() {
+		m.triggerCallback(state, IdleTimeout)
+	})
+
+	state.maximumTimer = m.clock.AfterFunc(maximumTimeout, func() {
+		m.triggerCallback(state, MaximumTimeout)
+	})
+
+	m.requests[id] = state
+	return nil
+}
+
+// Touch records activity on the request with the specified ID.
+// For requests started with StartIdleRequest it restarts the idle timer;
+// the maximum timeout is never extended.
+//
+// Returns ErrRequestNotFound if the request is not tracked.
+func (m *RequestLifecycleManager[T]) Touch(id ID[T]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, exists := m.requests[id]
+	if !exists {
+		return ErrRequestNotFound
+	}
+
+	m.touch(state)
+	return nil
+}
+
+// touch records activity on state and restarts its idle timer if it has one.
+// It reports whether the idle timer was restarted. The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) touch(state *requestState[T]) bool {
+	state.lastActivity = m.clock.Now()
+
+	if state.idleTimer == nil || !state.idleTimer.Stop() {
+		// Either not an idle request, or the timer already fired and
+		// the request is being cleaned up.
+		return false
+	}
+
+	state.idleTimer = m.clock.AfterFunc(state.idleTimeout, func() {
+		m.triggerCallback(state, IdleTimeout)
+	})
+	return true
+}
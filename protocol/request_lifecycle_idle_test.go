@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTouchExtendsIdleTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(1))
+
+	var triggered []TimeoutType
+	err := manager.StartIdleRequest(id, 10*time.Second, time.Minute, func(_ ID[int64], tt TimeoutType) {
+		triggered = append(triggered, tt)
+	})
+	if err != nil {
+		t.Fatalf("StartIdleRequest failed: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(9 * time.Second)
+		if err := manager.Touch(id); err != nil {
+			t.Fatalf("Touch failed: %v", err)
+		}
+	}
+	if len(triggered) != 0 {
+		t.Fatalf("Expected no timeout while touched, got %v", triggered)
+	}
+
+	clock.Advance(10 * time.Second)
+	if len(triggered) != 1 || triggered[0] != IdleTimeout {
+		t.Fatalf("Expected IdleTimeout, got %v", triggered)
+	}
+	if err := manager.Touch(id); !errors.Is(err, ErrRequestNotFound) {
+		t.Errorf("Expected ErrRequestNotFound after timeout, got %v", err)
+	}
+}
+
+func TestTouchDoesNotExtendMaximumTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(2))
+
+	var triggered []TimeoutType
+	manager.StartIdleRequest(id, 10*time.Second, 30*time.Second, func(_ ID[int64], tt TimeoutType) {
+		triggered = append(triggered, tt)
+	})
+
+	for i := 0; i < 6; i++ {
+		clock.Advance(5 * time.Second)
+		manager.Touch(id)
+	}
+	if len(triggered) != 1 || triggered[0] != MaximumTimeout {
+		t.Fatalf("Expected MaximumTimeout, got %v", triggered)
+	}
+	if clock.Pending() != 0 {
+		t.Errorf("Expected no pending timers, got %d", clock.Pending())
+	}
+}
+
+func TestResetTimeoutTouchesIdleRequest(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(3))
+
+	var triggered []TimeoutType
+	manager.StartIdleRequest(id, 10*time.Second, time.Minute, func(_ ID[int64], tt TimeoutType) {
+		triggered = append(triggered, tt)
+	})
+
+	clock.Advance(9 * time.Second)
+	if err := manager.ResetTimeout(id); err != nil {
+		t.Fatalf("ResetTimeout failed: %v", err)
+	}
+	clock.Advance(9 * time.Second)
+	if len(triggered) != 0 {
+		t.Fatalf("Expected idle timer to be restarted, got %v", triggered)
+	}
+	clock.Advance(time.Second)
+	if len(triggered) != 1 || triggered[0] != IdleTimeout {
+		t.Errorf("Expected IdleTimeout, got %v", triggered)
+	}
+}
+
+func TestTouchRequestWithoutIdleTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(4))
+
+	manager.StartRequest(id, time.Second, time.Minute, func(ID[int64], TimeoutType) {})
+	clock.Advance(500 * time.Millisecond)
+	if err := manager.Touch(id); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+
+	manager.mu.Lock()
+	lastActivity := manager.requests[id].lastActivity
+	manager.mu.Unlock()
+	if !lastActivity.Equal(time.Unix(0, 0).Add(500 * time.Millisecond)) {
+		t.Errorf("Expected activity to be recorded, got %v", lastActivity)
+	}
+}
+
+func TestStartIdleRequestValidation(t *testing.T) {
+	manager := NewRequestLifecycleManager[int64](nil)
+	callback := func(ID[int64], TimeoutType) {}
+
+	tests := []struct {
+		name     string
+		idle     time.Duration
+		maximum  time.Duration
+		callback func(ID[int64], TimeoutType)
+		expected error
+	}{
+		{"NilCallback", time.Second, time.Minute, nil, ErrCallbackNil},
+		{"IdleNotPositive", 0, time.Minute, callback, ErrIdleTimeoutNotPositive},
+		{"MaximumNotPositive", time.Second, 0, callback, ErrMaximumTimeoutNotPositive},
+		{"IdleExceedsMaximum", time.Minute, time.Minute, callback, ErrIdleTimeoutExceedsMaximum},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := manager.StartIdleRequest(NewID(int64(1)), test.idle, test.maximum, test.callback)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+
+	if err := manager.StartIdleRequest(ID[int64]{}, time.Second, time.Minute, callback); !errors.Is(err, ErrEmptyRequestID) {
+		t.Errorf("Expected ErrEmptyRequestID, got %v", err)
+	}
+}
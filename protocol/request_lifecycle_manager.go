@@ -19,6 +19,10 @@ const (
 	// MaximumTimeout indicates that the maximum allowed timeout has expired.
 	// At this point, the request is forcefully cleaned up.
 	MaximumTimeout
+
+	// IdleTimeout indicates that a request started with StartIdleRequest
+	// saw no activity for its idle timeout. The request is cleaned up.
+	IdleTimeout
 )
 
 func (t TimeoutType) String() string {
@@ -27,6 +31,8 @@ func (t TimeoutType) String() string {
 		return "SoftTimeout"
 	case MaximumTimeout:
 		return "MaximumTimeout"
+	case IdleTimeout:
+		return "IdleTimeout"
 	default:
 		return "UnknownTimeout"
 	}
@@ -38,11 +44,22 @@ type requestState[T IDConstraint] struct {
 	id             ID[T]
 	softTimeout    time.Duration
 	maximumTimeout time.Duration
-	softTimer      *time.Timer
-	maximumTimer   *time.Timer
+	softTimer      Timer
+	maximumTimer   Timer
 
 	onTimeout    func(ID[T], TimeoutType)
 	lastActivity time.Time
+
+	// idleTimeout and idleTimer replace the soft timer for requests started
+	// with StartIdleRequest.
+	idleTimeout time.Duration
+	idleTimer   Timer
+
+	// stages, stageTimers and onStage are set instead of the soft and maximum
+	// timers for requests started with StartStagedRequest.
+	stages      []TimeoutStage
+	stageTimers []Timer
+	onStage     func(ID[T], TimeoutStage)
 }
 
 // stop stops all active timers for the request.
@@ -55,6 +72,16 @@ func (s *requestState[T]) stop() {
 		s.maximumTimer.Stop()
 		s.maximumTimer = nil
 	}
+	if s.idleTimer != nil {
+		s.idleTimer.Stop()
+		s.idleTimer = nil
+	}
+	for i, timer := range s.stageTimers {
+		if timer != nil {
+			timer.Stop()
+			s.stageTimers[i] = nil
+		}
+	}
 }
 
 // RequestLifecycleManager manages the lifecycle of MCP protocol requests.
@@ -62,8 +89,8 @@ func (s *requestState[T]) stop() {
 //
 // Typical usage:
 //
-//	manager := NewRequestLifecycleManager[string](сontext.Background())
-//	err := manager.StartRequest(NewID("request-123"), 5*time.Second, 30*time.Second, func(id IDType[string], t TimeoutType) {
+//	manager := NewRequestLifecycleManager[string](context.Background())
+//	err := manager.StartRequest(NewID("request-123"), 5*time.Second, 30*time.Second, func(id ID[string], t TimeoutType) {
 //	    log.Printf("Request %s timed out: %s", id, t)
 //	})
 //
@@ -81,6 +108,18 @@ type RequestLifecycleManager[T IDConstraint] struct {
 	wg sync.WaitGroup
 
 	onError func(ID[T], error)
+
+	sendCancelled func(Notification) error
+
+	clock Clock
+
+	allowZeroIDs bool
+
+	// usedIDTTL, releasedAt, janitor and reclaimed implement WithUsedIDTTL.
+	usedIDTTL  time.Duration
+	releasedAt map[ID[T]]time.Time
+	janitor    Timer
+	reclaimed  int
 }
 
 type RequestLifecycleOption[T IDConstraint] func(*RequestLifecycleManager[T])
@@ -91,6 +130,50 @@ func WithErrorHandler[T IDConstraint](fn func(ID[T], error)) RequestLifecycleOpt
 	}
 }
 
+// WithCancellationNotifier makes the manager send a notifications/cancelled message
+// through send for every request that times out, and for every request still active
+// when StopAll is called, so the peer stops working on requests nobody waits for anymore.
+//
+// The notification is sent before the timeout callback runs. Send errors and panics in
+// send are reported to the handler configured with WithErrorHandler, or dropped if there
+// is none; the timeout callback still runs.
+//
+// Example:
+//
+//	manager := NewRequestLifecycleManager[int64](ctx, WithCancellationNotifier[int64](session.Notify))
+func WithCancellationNotifier[T IDConstraint](send func(Notification) error) RequestLifecycleOption[T] {
+	return func(m *RequestLifecycleManager[T]) {
+		m.sendCancelled = send
+	}
+}
+
+// WithZeroIDs makes the manager accept numeric request IDs equal to 0,
+// which it otherwise rejects as empty. It affects only this manager.
+//
+// Example:
+//
+//	manager := protocol.NewRequestLifecycleManager[int64](ctx, protocol.WithZeroIDs[int64]())
+func WithZeroIDs[T IDConstraint]() RequestLifecycleOption[T] {
+	return func(m *RequestLifecycleManager[T]) {
+		m.allowZeroIDs = true
+	}
+}
+
+// WithClock makes the manager use clock for timeouts instead of the system clock.
+// Pass a FakeClock to test timeout handling without waiting for real time to pass.
+//
+// Example:
+//
+//	clock := protocol.NewFakeClock(time.Now())
+//	manager := protocol.NewRequestLifecycleManager[int64](ctx, protocol.WithClock[int64](clock))
+func WithClock[T IDConstraint](clock Clock) RequestLifecycleOption[T] {
+	return func(m *RequestLifecycleManager[T]) {
+		if clock != nil {
+			m.clock = clock
+		}
+	}
+}
+
 // NewRequestLifecycleManager creates and returns a new RequestLifecycleManager.
 // Call StopAll() when the manager is no longer needed to clean up resources.
 func NewRequestLifecycleManager[T IDConstraint](ctx context.Context, opts ...RequestLifecycleOption[T]) *RequestLifecycleManager[T] {
@@ -104,11 +187,13 @@ func NewRequestLifecycleManager[T IDConstraint](ctx context.Context, opts ...Req
 		usedIDs:  make(map[ID[T]]struct{}),
 		ctx:      ctx,
 		cancel:   cancel,
+		clock:    systemClock{},
 	}
 
 	for _, opt := range opts {
 		opt(manager)
 	}
+	manager.startJanitor()
 	return manager
 }
 
@@ -145,20 +230,10 @@ func (m *RequestLifecycleManager[T]) StartRequest(
 		return ErrCallbackNil
 	}
 
-	if id.isEmpty() {
+	if !m.validID(id) {
 		return ErrEmptyRequestID
 	}
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if _, used := m.usedIDs[id]; used {
-		return ErrDuplicateRequestID
-	}
-
-	m.usedIDs[id] = struct{}{}
-
 	if softTimeout <= 0 {
-
 		return ErrSoftTimeoutNotPositive
 	}
 	if maximumTimeout <= 0 {
@@ -168,21 +243,30 @@ func (m *RequestLifecycleManager[T]) StartRequest(
 		return ErrSoftTimeoutExceedsMaximum
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, used := m.usedIDs[id]; used {
+		return ErrDuplicateRequestID
+	}
+
+	m.usedIDs[id] = struct{}{}
+
 	state := &requestState[T]{
 		id:             id,
 		softTimeout:    softTimeout,
 		maximumTimeout: maximumTimeout,
-		lastActivity:   time.Now(),
+		lastActivity:   m.clock.Now(),
 		onTimeout:      onTimeout,
 	}
 
 	m.wg.Add(1)
 
-	state.softTimer = time.AfterFunc(softTimeout, func() {
+	state.softTimer = m.clock.AfterFunc(softTimeout, func() {
 		m.triggerCallback(state, SoftTimeout)
 	})
 
-	state.maximumTimer = time.AfterFunc(maximumTimeout, func() {
+	state.maximumTimer = m.clock.AfterFunc(maximumTimeout, func() {
 		m.triggerCallback(state, MaximumTimeout)
 	})
 
@@ -195,6 +279,7 @@ func (m *RequestLifecycleManager[T]) StartRequest(
 // Returns an error if:
 //   - The request is not found.
 //   - The provided callback is nil.
+//   - The request was started with StartStagedRequest (ErrStagedRequest).
 func (m *RequestLifecycleManager[T]) UpdateCallback(id ID[T], newCallback func(ID[T], TimeoutType)) error {
 	if newCallback == nil {
 		return ErrCallbackNil
@@ -207,9 +292,12 @@ func (m *RequestLifecycleManager[T]) UpdateCallback(id ID[T], newCallback func(I
 	if !exists {
 		return ErrRequestNotFound
 	}
+	if len(state.stages) > 0 {
+		return ErrStagedRequest
+	}
 
 	state.onTimeout = newCallback
-	state.lastActivity = time.Now()
+	state.lastActivity = m.clock.Now()
 	return nil
 }
 
@@ -221,6 +309,10 @@ func (m *RequestLifecycleManager[T]) CompleteRequest(id ID[T]) {
 
 // ResetTimeout resets the soft timeout timer for the specified request.
 // Useful when receiving progress notifications to extend the active period.
+//
+// For requests started with StartStagedRequest, every stage except the last one
+// is restarted, including stages that have already fired. For requests started
+// with StartIdleRequest it is equivalent to Touch.
 func (m *RequestLifecycleManager[T]) ResetTimeout(id ID[T]) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -230,18 +322,34 @@ func (m *RequestLifecycleManager[T]) ResetTimeout(id ID[T]) error {
 		return ErrRequestNotFound
 	}
 
+	m.resetTimeout(state)
+	return nil
+}
+
+// resetTimeout implements ResetTimeout for a tracked request. The caller must hold m.mu.
+// It reports whether a timer was restarted; false means the timeout has already fired.
+func (m *RequestLifecycleManager[T]) resetTimeout(state *requestState[T]) bool {
+	if len(state.stages) > 0 {
+		m.restartStages(state)
+		state.lastActivity = m.clock.Now()
+		return true
+	}
+	if state.idleTimeout > 0 {
+		return m.touch(state)
+	}
+
 	if state.softTimer != nil {
 		if !state.softTimer.Stop() {
-			return nil
+			return false
 		}
 	}
 
-	state.softTimer = time.AfterFunc(state.softTimeout, func() {
+	state.softTimer = m.clock.AfterFunc(state.softTimeout, func() {
 		m.triggerCallback(state, SoftTimeout)
 	})
 
-	state.lastActivity = time.Now()
-	return nil
+	state.lastActivity = m.clock.Now()
+	return true
 }
 
 // ActiveIDs returns a snapshot list of currently active request IDs.
@@ -267,10 +375,19 @@ func (m *RequestLifecycleManager[T]) StopAll(wait bool) []ID[T] {
 	for id, state := range m.requests {
 		state.stop()
 		ids = append(ids, id)
+		m.wg.Done()
 	}
 	m.requests = make(map[ID[T]]*requestState[T])
+	if m.janitor != nil {
+		m.janitor.Stop()
+		m.janitor = nil
+	}
 	m.mu.Unlock()
 
+	for _, id := range ids {
+		m.notifyCancelled(id, "session shutting down")
+	}
+
 	if wait {
 		m.wg.Wait()
 	}
@@ -278,6 +395,11 @@ func (m *RequestLifecycleManager[T]) StopAll(wait bool) []ID[T] {
 	return ids
 }
 
+// validID reports whether id can be tracked, honouring WithZeroIDs.
+func (m *RequestLifecycleManager[T]) validID(id ID[T]) bool {
+	return id.isValidAllowingZero(m.allowZeroIDs)
+}
+
 // triggerCallback is an internal method that handles timeout events.
 // It first checks if the manager context has been cancelled before proceeding.
 func (m *RequestLifecycleManager[T]) triggerCallback(state *requestState[T], t TimeoutType) {
@@ -292,27 +414,63 @@ func (m *RequestLifecycleManager[T]) triggerCallback(state *requestState[T], t T
 	m.mu.Unlock()
 
 	if m.cleanupRequest(state.id) {
-		defer func() {
-			if r := recover(); r != nil {
-				err := fmt.Errorf("callback panic: %v", r)
-				if m.onError != nil {
-					m.onError(state.id, err)
-				} else {
-					fmt.Printf("Request %v callback panicked: %v\n", state.id, r)
-				}
-			}
-		}()
+		defer m.recoverCallback(state.id)
 
+		m.notifyCancelled(state.id, fmt.Sprintf("request exceeded %s", t))
 		onTimeoutCopy(state.id, t)
 	}
 }
 
+// recoverCallback reports a panic raised by a timeout callback.
+// It must be called directly by a deferred statement.
+func (m *RequestLifecycleManager[T]) recoverCallback(id ID[T]) {
+	if r := recover(); r != nil {
+		err := fmt.Errorf("callback panic: %v", r)
+		if m.onError != nil {
+			m.onError(id, err)
+		} else {
+			fmt.Printf("Request %v callback panicked: %v\n", id, r)
+		}
+	}
+}
+
+// notifyCancelled sends a cancellation notification for id if a notifier is configured.
+// Send errors and panics in the notifier are reported to the error handler, or dropped
+// without one, so they never prevent the timeout callback from running.
+func (m *RequestLifecycleManager[T]) notifyCancelled(id ID[T], reason string) {
+	if m.sendCancelled == nil {
+		return
+	}
+	if err := m.sendCancellation(id, reason); err != nil && m.onError != nil {
+		m.onError(id, err)
+	}
+}
+
+// sendCancellation calls the cancellation notifier, turning a panic into an error.
+func (m *RequestLifecycleManager[T]) sendCancellation(id ID[T], reason string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cancellation notifier panic: %v", r)
+		}
+	}()
+
+	if err := m.sendCancelled(NewCancelledNotification(id, reason)); err != nil {
+		return fmt.Errorf("send cancellation: %w", err)
+	}
+	return nil
+}
+
 // cleanupRequest stops timers and removes the request from tracking.
 // Returns true if the request was found and cleaned up.
 func (m *RequestLifecycleManager[T]) cleanupRequest(id ID[T]) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.removeRequest(id)
+}
+
+// removeRequest implements cleanupRequest. The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) removeRequest(id ID[T]) bool {
 	state, exists := m.requests[id]
 	if !exists {
 		return false
@@ -321,6 +479,9 @@ func (m *RequestLifecycleManager[T]) cleanupRequest(id ID[T]) bool {
 	state.stop()
 
 	delete(m.requests, id)
+	if m.releasedAt != nil {
+		m.releasedAt[id] = m.clock.Now()
+	}
 	m.wg.Done()
 
 	return true
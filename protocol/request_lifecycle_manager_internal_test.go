@@ -27,7 +27,7 @@ func TestTriggerCallbackContextAlreadyCancelled(t *testing.T) {
 	}
 
 	state := &requestState[string]{
-		id:        newID("ctx-done"),
+		id:        NewID("ctx-done"),
 		onTimeout: func(ID[string], TimeoutType) {},
 	}
 
@@ -38,7 +38,7 @@ func TestTriggerCallbackContextAlreadyCancelled(t *testing.T) {
 func TestTriggerCallbackPanicNoErrorHandler(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
 
-	id := newID("panic-no-handler")
+	id := NewID("panic-no-handler")
 
 	state := &requestState[string]{
 		id:        id,
@@ -56,7 +56,7 @@ func TestStopAllWaitTriggersWaitGroup(t *testing.T) {
 	ctx := context.Background()
 	manager := NewRequestLifecycleManager[string](ctx)
 
-	id := newID("test-stopall-wait")
+	id := NewID("test-stopall-wait")
 
 	triggered := make(chan struct{})
 	blockDone := make(chan struct{})
@@ -98,7 +98,7 @@ func TestStopAllWaitTriggersWaitGroup(t *testing.T) {
 func TestResetTimeout_TimerStopReturnsFalse(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
 
-	id := newID("reset-false-stop")
+	id := NewID("reset-false-stop")
 
 	timer := time.NewTimer(1 * time.Millisecond)
 	time.Sleep(10 * time.Millisecond)
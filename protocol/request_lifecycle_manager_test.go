@@ -37,6 +37,7 @@ func TestTimeoutTypeString(t *testing.T) {
 	}{
 		{SoftTimeout, "SoftTimeout"},
 		{MaximumTimeout, "MaximumTimeout"},
+		{IdleTimeout, "IdleTimeout"},
 		{TimeoutType(42), "UnknownTimeout"},
 	}
 
@@ -71,7 +72,7 @@ func TestWithErrorHandler(t *testing.T) {
 
 	manager := NewRequestLifecycleManager[string](context.Background(), WithErrorHandler(handler))
 
-	testID := newID("test-id")
+	testID := NewID("test-id")
 	testErr := errors.New("test error")
 	manager.onError(testID, testErr)
 
@@ -111,7 +112,7 @@ func TestRequestLifecycleManagerLen(t *testing.T) {
 
 func TestStartRequestValid(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("valid-id")
+	id := NewID("valid-id")
 	err := manager.StartRequest(id, 1*time.Second, 2*time.Second, func(ID[string], TimeoutType) {})
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -120,7 +121,7 @@ func TestStartRequestValid(t *testing.T) {
 
 func TestUpdateCallback(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("update-callback")
+	id := NewID("update-callback")
 	callback := func(ID[string], TimeoutType) {}
 	manager.StartRequest(id, 1*time.Second, 2*time.Second, callback)
 
@@ -135,7 +136,7 @@ func TestUpdateCallback(t *testing.T) {
 	}
 
 	// Non-existent ID
-	nonExistentID := newID("non-existent")
+	nonExistentID := NewID("non-existent")
 	if err := manager.UpdateCallback(nonExistentID, newCallback); !errors.Is(err, ErrRequestNotFound) {
 		t.Errorf("Expected ErrRequestNotFound, got %v", err)
 	}
@@ -143,7 +144,7 @@ func TestUpdateCallback(t *testing.T) {
 
 func TestCompleteRequest(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("complete-request")
+	id := NewID("complete-request")
 	callback := func(ID[string], TimeoutType) {}
 	manager.StartRequest(id, 1*time.Second, 2*time.Second, callback)
 
@@ -156,7 +157,7 @@ func TestCompleteRequest(t *testing.T) {
 
 func TestResetTimeoutSuccess(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("reset-success")
+	id := NewID("reset-success")
 	manager.StartRequest(id, 1*time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {})
 
 	err := manager.ResetTimeout(id)
@@ -167,7 +168,7 @@ func TestResetTimeoutSuccess(t *testing.T) {
 
 func TestResetTimeoutUnknownID(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("unknown-reset")
+	id := NewID("unknown-reset")
 	err := manager.ResetTimeout(id)
 	if !errors.Is(err, ErrRequestNotFound) {
 		t.Errorf("Expected ErrRequestNotFound, got: %v", err)
@@ -176,8 +177,8 @@ func TestResetTimeoutUnknownID(t *testing.T) {
 
 func TestActiveIDs(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id1 := newID("active-1")
-	id2 := newID("active-2")
+	id1 := NewID("active-1")
+	id2 := NewID("active-2")
 	cb := func(ID[string], TimeoutType) {}
 	manager.StartRequest(id1, time.Second, 2*time.Second, cb)
 	manager.StartRequest(id2, time.Second, 2*time.Second, cb)
@@ -190,7 +191,7 @@ func TestActiveIDs(t *testing.T) {
 
 func TestTriggerCallbackWithErrorHandler(t *testing.T) {
 	var caught error
-	id := newID("panic-handler")
+	id := NewID("panic-handler")
 
 	handler := func(_ ID[string], err error) {
 		caught = err
@@ -216,7 +217,7 @@ func TestTriggerCallbackWithErrorHandler(t *testing.T) {
 }
 
 func TestTriggerCallbackWithoutErrorHandler(t *testing.T) {
-	id := newID("panic-no-handler")
+	id := NewID("panic-no-handler")
 	manager := NewRequestLifecycleManager[string](context.Background())
 
 	state := &requestState[string]{
@@ -235,7 +236,7 @@ func TestTriggerCallbackWithoutErrorHandler(t *testing.T) {
 
 func TestCleanupRequest(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("cleanup-test")
+	id := NewID("cleanup-test")
 	manager.StartRequest(id, time.Second, 2*time.Second, func(ID[string], TimeoutType) {})
 
 	ok := manager.cleanupRequest(id)
@@ -243,7 +244,7 @@ func TestCleanupRequest(t *testing.T) {
 		t.Error("Expected cleanupRequest to return true for existing ID")
 	}
 
-	notFound := manager.cleanupRequest(newID("not-found"))
+	notFound := manager.cleanupRequest(NewID("not-found"))
 	if notFound {
 		t.Error("Expected cleanupRequest to return false for non-existing ID")
 	}
@@ -251,7 +252,7 @@ func TestCleanupRequest(t *testing.T) {
 
 func TestStartRequestReturnsErrSoftTimeoutNotPositive(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("soft-zero")
+	id := NewID("soft-zero")
 	err := manager.StartRequest(id, 0, time.Second, func(ID[string], TimeoutType) {})
 	if err != ErrSoftTimeoutNotPositive {
 		t.Errorf("Expected ErrSoftTimeoutNotPositive, got: %v", err)
@@ -260,7 +261,7 @@ func TestStartRequestReturnsErrSoftTimeoutNotPositive(t *testing.T) {
 
 func TestStartRequestReturnsErrMaximumTimeoutNotPositive(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("max-zero")
+	id := NewID("max-zero")
 	err := manager.StartRequest(id, time.Second, 0, func(ID[string], TimeoutType) {})
 	if err != ErrMaximumTimeoutNotPositive {
 		t.Errorf("Expected ErrMaximumTimeoutNotPositive, got: %v", err)
@@ -269,7 +270,7 @@ func TestStartRequestReturnsErrMaximumTimeoutNotPositive(t *testing.T) {
 
 func TestStartRequestReturnsErrSoftTimeoutExceedsMaximum(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("soft-exceeds")
+	id := NewID("soft-exceeds")
 	err := manager.StartRequest(id, 2*time.Second, 1*time.Second, func(ID[string], TimeoutType) {})
 	if err != ErrSoftTimeoutExceedsMaximum {
 		t.Errorf("Expected ErrSoftTimeoutExceedsMaximum, got: %v", err)
@@ -278,7 +279,7 @@ func TestStartRequestReturnsErrSoftTimeoutExceedsMaximum(t *testing.T) {
 
 func TestStartRequestWithNilCallback(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("test-id")
+	id := NewID("test-id")
 
 	err := manager.StartRequest(id, 1*time.Second, 2*time.Second, nil)
 	if !errors.Is(err, ErrCallbackNil) {
@@ -298,7 +299,7 @@ func TestStartRequestWithEmptyID(t *testing.T) {
 
 func TestStartRequestWithDuplicateID(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("duplicate-id")
+	id := NewID("duplicate-id")
 
 	// Первый запуск - должен быть успешным
 	err := manager.StartRequest(id, 1*time.Second, 2*time.Second, func(ID[string], TimeoutType) {})
@@ -333,55 +334,52 @@ func TestStopAllWithEmptyRequestList(t *testing.T) {
 }
 
 func TestStopAllWithMultipleRequests(t *testing.T) {
-	t.Skip("This test is flaky and may hang when run in CI environment")
-	// Оригинальный код оставлен для справки
-
-	// manager := NewRequestLifecycleManager[string](context.Background())
-	//
-	// // Создаем несколько запросов
-	// id1 := newID("stop-all-1")
-	// id2 := newID("stop-all-2")
-	// id3 := newID("stop-all-3")
-	//
-	// callback := func(ID[string], TimeoutType) {}
-	//
-	// manager.StartRequest(id1, time.Second, 2*time.Second, callback)
-	// manager.StartRequest(id2, time.Second, 2*time.Second, callback)
-	// manager.StartRequest(id3, time.Second, 2*time.Second, callback)
-	//
-	// // Проверяем, что все запросы активны
-	// if manager.Len() != 3 {
-	// 	t.Fatalf("Expected 3 active requests, got %d", manager.Len())
-	// }
-	//
-	// // Останавливаем все запросы
-	// ids := manager.StopAll(true)
-	//
-	// // Проверяем длину полученного списка идентификаторов
-	// if len(ids) != 3 {
-	// 	t.Errorf("Expected 3 IDs returned, got %d", len(ids))
-	// }
-	//
-	// // Проверяем что все запросы были удалены
-	// if manager.Len() != 0 {
-	// 	t.Errorf("Expected 0 active requests after StopAll, got %d", manager.Len())
-	// }
-	//
-	// // Проверяем, что идентификаторы в списке соответствуют нашим запросам
-	// idMap := make(map[ID[string]]bool)
-	// for _, id := range ids {
-	// 	idMap[id] = true
-	// }
-	//
-	// if !idMap[id1] || !idMap[id2] || !idMap[id3] {
-	// 	t.Error("Not all request IDs were returned by StopAll")
-	// }
+	manager := NewRequestLifecycleManager[string](context.Background())
+
+	// Создаем несколько запросов
+	id1 := NewID("stop-all-1")
+	id2 := NewID("stop-all-2")
+	id3 := NewID("stop-all-3")
+
+	callback := func(ID[string], TimeoutType) {}
+
+	manager.StartRequest(id1, time.Second, 2*time.Second, callback)
+	manager.StartRequest(id2, time.Second, 2*time.Second, callback)
+	manager.StartRequest(id3, time.Second, 2*time.Second, callback)
+
+	// Проверяем, что все запросы активны
+	if manager.Len() != 3 {
+		t.Fatalf("Expected 3 active requests, got %d", manager.Len())
+	}
+
+	// Останавливаем все запросы
+	ids := manager.StopAll(true)
+
+	// Проверяем длину полученного списка идентификаторов
+	if len(ids) != 3 {
+		t.Errorf("Expected 3 IDs returned, got %d", len(ids))
+	}
+
+	// Проверяем что все запросы были удалены
+	if manager.Len() != 0 {
+		t.Errorf("Expected 0 active requests after StopAll, got %d", manager.Len())
+	}
+
+	// Проверяем, что идентификаторы в списке соответствуют нашим запросам
+	idMap := make(map[ID[string]]bool)
+	for _, id := range ids {
+		idMap[id] = true
+	}
+
+	if !idMap[id1] || !idMap[id2] || !idMap[id3] {
+		t.Error("Not all request IDs were returned by StopAll")
+	}
 }
 
 // Более простой тест для ResetTimeout, когда таймер не может быть остановлен
 func TestResetTimeoutWithNilTimer(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("nil-timer")
+	id := NewID("nil-timer")
 
 	// Создаем запрос и сразу устанавливаем таймер в nil
 	manager.StartRequest(id, time.Second, 2*time.Second, func(ID[string], TimeoutType) {})
@@ -402,7 +400,7 @@ func TestResetTimeoutWithNilTimer(t *testing.T) {
 // Простой тест для функции triggerCallback с обычным колбэком
 func TestTriggerCallbackWithSimpleCallback(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("simple-trigger")
+	id := NewID("simple-trigger")
 
 	callbackExecuted := false
 	callback := func(ID[string], TimeoutType) {
@@ -433,7 +431,7 @@ func TestTriggerCallbackWithSimpleCallback(t *testing.T) {
 func TestTriggerCallbackHandlesPanic(t *testing.T) {
 	// Создаем менеджер без обработчика ошибок
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("panic-test")
+	id := NewID("panic-test")
 
 	// Запрос с колбэком, который вызывает панику
 	manager.StartRequest(id, time.Second, 2*time.Second, func(ID[string], TimeoutType) {
@@ -460,7 +458,7 @@ func TestTriggerCallbackHandlesPanic(t *testing.T) {
 // Тест для проверки случая, когда Stop() возвращает false в ResetTimeout
 func TestResetTimeoutWithUnstoppableTimer(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("unstoppable-timer")
+	id := NewID("unstoppable-timer")
 
 	// Создаем запрос
 	manager.StartRequest(id, time.Second, 2*time.Second, func(ID[string], TimeoutType) {})
@@ -489,7 +487,7 @@ func TestResetTimeoutWithUnstoppableTimer(t *testing.T) {
 // Простой тест для StopAll без ожидания
 func TestStopAllNoWait(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("stop-no-wait")
+	id := NewID("stop-no-wait")
 
 	// Создаем один запрос
 	manager.StartRequest(id, time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {})
@@ -506,7 +504,7 @@ func TestStopAllNoWait(t *testing.T) {
 // TestTriggerCallbackWithMaximumTimeout проверяет вызов функции triggerCallback с параметром MaximumTimeout
 func TestTriggerCallbackWithMaximumTimeout(t *testing.T) {
 	manager := NewRequestLifecycleManager[string](context.Background())
-	id := newID("maximum-timeout-trigger")
+	id := NewID("maximum-timeout-trigger")
 
 	// Отслеживаем, был ли вызван колбэк и с каким типом таймаута
 	var capturedTimeout TimeoutType
@@ -534,3 +532,208 @@ func TestTriggerCallbackWithMaximumTimeout(t *testing.T) {
 		t.Error("Request was not removed after triggerCallback with MaximumTimeout")
 	}
 }
+
+func TestStartRequestZeroIDOptIn(t *testing.T) {
+	t.Parallel()
+	callback := func(ID[int64], TimeoutType) {}
+
+	manager := NewRequestLifecycleManager[int64](context.Background())
+	defer manager.StopAll(false)
+	if err := manager.StartRequest(NewID(int64(0)), time.Hour, 2*time.Hour, callback); err != ErrEmptyRequestID {
+		t.Errorf("Expected ErrEmptyRequestID, got %v", err)
+	}
+
+	zeroManager := NewRequestLifecycleManager[int64](context.Background(), WithZeroIDs[int64]())
+	defer zeroManager.StopAll(false)
+	if err := zeroManager.StartRequest(NewID(int64(0)), time.Hour, 2*time.Hour, callback); err != nil {
+		t.Errorf("Expected zero ID to be accepted, got %v", err)
+	}
+	if err := zeroManager.StartIdleRequest(NewID(int64(0)), time.Hour, 2*time.Hour, callback); err != ErrDuplicateRequestID {
+		t.Errorf("Expected zero ID to be tracked as used, got %v", err)
+	}
+
+	stringManager := NewRequestLifecycleManager[string](context.Background(), WithZeroIDs[string]())
+	defer stringManager.StopAll(false)
+	if err := stringManager.StartRequest(NewID(""), time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {}); err != ErrEmptyRequestID {
+		t.Errorf("Expected empty string ID to stay rejected, got %v", err)
+	}
+
+	zeroIntManager := NewRequestLifecycleManager[ZeroInt](context.Background())
+	defer zeroIntManager.StopAll(false)
+	if err := zeroIntManager.StartRequest(NewID(ZeroInt(0)), time.Hour, 2*time.Hour, func(ID[ZeroInt], TimeoutType) {}); err != nil {
+		t.Errorf("Expected ZeroInt zero ID to be accepted, got %v", err)
+	}
+}
+
+func TestCancellationNotifierOnTimeout(t *testing.T) {
+	var sent []Notification
+	notifier := func(n Notification) error {
+		sent = append(sent, n)
+		return nil
+	}
+
+	manager := NewRequestLifecycleManager[string](context.Background(), WithCancellationNotifier[string](notifier))
+	id := NewID("cancel-on-timeout")
+
+	var called bool
+	if err := manager.StartRequest(id, time.Hour, 2*time.Hour, func(ID[string], TimeoutType) { called = true }); err != nil {
+		t.Fatalf("StartRequest failed: %v", err)
+	}
+
+	manager.triggerCallback(manager.requests[id], SoftTimeout)
+
+	if !called {
+		t.Error("Expected timeout callback to be called")
+	}
+	if len(sent) != 1 {
+		t.Fatalf("Expected 1 cancellation notification, got %d", len(sent))
+	}
+	if sent[0].GetMethod() != NotificationCancelled {
+		t.Errorf("Expected %s, got %s", NotificationCancelled, sent[0].GetMethod())
+	}
+	params, ok := sent[0].GetParams().(CancelledNotificationParams)
+	if !ok {
+		t.Fatalf("Expected CancelledNotificationParams, got %T", sent[0].GetParams())
+	}
+	if params.RequestID != id || params.Reason != "request exceeded SoftTimeout" {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+}
+
+func TestCancellationNotifierOnStopAll(t *testing.T) {
+	var sent []Notification
+	notifier := func(n Notification) error {
+		sent = append(sent, n)
+		return nil
+	}
+
+	manager := NewRequestLifecycleManager[string](context.Background(), WithCancellationNotifier[string](notifier))
+	manager.StartRequest(NewID("stop-1"), time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {})
+	manager.StartRequest(NewID("stop-2"), time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {})
+
+	manager.StopAll(false)
+
+	if len(sent) != 2 {
+		t.Fatalf("Expected 2 cancellation notifications, got %d", len(sent))
+	}
+	for _, n := range sent {
+		params := n.GetParams().(CancelledNotificationParams)
+		if params.Reason != "session shutting down" {
+			t.Errorf("Unexpected reason: %q", params.Reason)
+		}
+	}
+}
+
+func TestCancellationNotifierReportsSendErrors(t *testing.T) {
+	sendErr := errors.New("connection closed")
+	var caught error
+
+	manager := NewRequestLifecycleManager[string](context.Background(),
+		WithCancellationNotifier[string](func(Notification) error { return sendErr }),
+		WithErrorHandler(func(_ ID[string], err error) { caught = err }),
+	)
+	id := NewID("cancel-send-error")
+	manager.StartRequest(id, time.Hour, 2*time.Hour, func(ID[string], TimeoutType) {})
+
+	manager.triggerCallback(manager.requests[id], MaximumTimeout)
+
+	if !errors.Is(caught, sendErr) {
+		t.Errorf("Expected send error to reach error handler, got %v", caught)
+	}
+}
+
+func TestCancellationNotifierPanicDoesNotSkipCallback(t *testing.T) {
+	var caught error
+	manager := NewRequestLifecycleManager[string](context.Background(),
+		WithCancellationNotifier[string](func(Notification) error { panic("notifier down") }),
+		WithErrorHandler(func(_ ID[string], err error) { caught = err }),
+	)
+	id := NewID("cancel-notifier-panic")
+
+	var called bool
+	manager.StartRequest(id, time.Hour, 2*time.Hour, func(ID[string], TimeoutType) { called = true })
+
+	manager.triggerCallback(manager.requests[id], SoftTimeout)
+
+	if !called {
+		t.Error("Expected timeout callback to run after the notifier panicked")
+	}
+	if caught == nil || !strings.Contains(caught.Error(), "notifier down") {
+		t.Errorf("Expected notifier panic to reach error handler, got %v", caught)
+	}
+
+	// Without an error handler the panic is dropped, like a send error.
+	silent := NewRequestLifecycleManager[string](context.Background(),
+		WithCancellationNotifier[string](func(Notification) error { panic("notifier down") }),
+	)
+	called = false
+	silent.StartRequest(id, time.Hour, 2*time.Hour, func(ID[string], TimeoutType) { called = true })
+	silent.triggerCallback(silent.requests[id], SoftTimeout)
+	if !called {
+		t.Error("Expected timeout callback to run without an error handler")
+	}
+}
+
+func TestStartRequestTriggersSoftTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[string](nil, WithClock[string](clock))
+	id := NewID("trigger-soft")
+
+	var triggered []TimeoutType
+	err := manager.StartRequest(id, 10*time.Millisecond, time.Second, func(_ ID[string], tt TimeoutType) {
+		triggered = append(triggered, tt)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	clock.Advance(9 * time.Millisecond)
+	if len(triggered) != 0 {
+		t.Fatalf("Expected no timeout before 10ms, got: %v", triggered)
+	}
+
+	clock.Advance(time.Millisecond)
+	if len(triggered) != 1 || triggered[0] != SoftTimeout {
+		t.Fatalf("Expected a single SoftTimeout, got: %v", triggered)
+	}
+	if manager.Len() != 0 || clock.Pending() != 0 {
+		t.Errorf("Expected request and timers to be cleaned up, got %d requests and %d timers", manager.Len(), clock.Pending())
+	}
+}
+
+func TestStartRequestTriggersMaximumTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[string](nil, WithClock[string](clock))
+	id := NewID("trigger-maximum")
+
+	var triggered []TimeoutType
+	err := manager.StartRequest(id, 10*time.Millisecond, 15*time.Millisecond, func(_ ID[string], tt TimeoutType) {
+		triggered = append(triggered, tt)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Progress pushes the soft timeout past the maximum one.
+	clock.Advance(8 * time.Millisecond)
+	if err := manager.ResetTimeout(id); err != nil {
+		t.Fatalf("ResetTimeout failed: %v", err)
+	}
+
+	clock.Advance(7 * time.Millisecond)
+	if len(triggered) != 1 || triggered[0] != MaximumTimeout {
+		t.Fatalf("Expected a single MaximumTimeout, got: %v", triggered)
+	}
+
+	clock.Advance(time.Hour)
+	if len(triggered) != 1 {
+		t.Errorf("Expected no callbacks after cleanup, got: %v", triggered)
+	}
+}
+
+func TestWithClockIgnoresNil(t *testing.T) {
+	manager := NewRequestLifecycleManager[string](nil, WithClock[string](nil))
+	if _, ok := manager.clock.(systemClock); !ok {
+		t.Errorf("Expected system clock, got %T", manager.clock)
+	}
+}
@@ -0,0 +1,133 @@
+package protocol
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeoutStage is one step of an escalating request timeout.
+type TimeoutStage struct {
+	// Name identifies the stage in the callback, e.g. "warn" or "cancel".
+	Name string
+	// After is how long after the request started the stage fires.
+	After time.Duration
+}
+
+// StartStagedRequest begins tracking a request whose timeout escalates through
+// an ordered list of stages instead of a single soft and maximum timeout.
+//
+// onStage is called with each stage as it fires. The last stage is final: the
+// request stops being tracked before its callback runs, and a cancellation
+// notification is sent if WithCancellationNotifier is configured. ResetTimeout
+// restarts every stage except the last, which acts as the maximum timeout.
+// UpdateCallback returns ErrStagedRequest for staged requests.
+//
+// Returns an error if:
+//   - The request ID has already been used in this session.
+//   - No stages are given, or their durations are not positive and strictly increasing.
+//
+// Example:
+//
+//	err := manager.StartStagedRequest(id, []protocol.TimeoutStage{
+//	    {Name: "warn", After: 5 * time.Second},
+//	    {Name: "cancel", After: 30 * time.Second},
+//	    {Name: "cleanup", After: 60 * time.Second},
+//	}, func(id protocol.ID[int64], stage protocol.TimeoutStage) {
+//	    log.Printf("request %s reached %s", id, stage.Name)
+//	})
+func (m *RequestLifecycleManager[T]) StartStagedRequest(
+	id ID[T],
+	stages []TimeoutStage,
+	onStage func(ID[T], TimeoutStage),
+) error {
+	if onStage == nil {
+		return ErrCallbackNil
+	}
+	if !m.validID(id) {
+		return ErrEmptyRequestID
+	}
+	if len(stages) == 0 {
+		return ErrNoTimeoutStages
+	}
+	var previous time.Duration
+	for _, stage := range stages {
+		if stage.After <= previous {
+			return ErrTimeoutStagesNotOrdered
+		}
+		previous = stage.After
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, used := m.usedIDs[id]; used {
+		return ErrDuplicateRequestID
+	}
+	m.usedIDs[id] = struct{}{}
+
+	state := &requestState[T]{
+		id:           id,
+		lastActivity: m.clock.Now(),
+		stages:       append([]TimeoutStage(nil), stages...),
+		stageTimers:  make([]Timer, len(stages)),
+		onStage:      onStage,
+	}
+
+	m.wg.Add(1)
+	for i := range state.stages {
+		m.scheduleStage(state, i, state.stages[i].After)
+	}
+
+	m.requests[id] = state
+	return nil
+}
+
+// scheduleStage arms the timer for stage i. The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) scheduleStage(state *requestState[T], i int, after time.Duration) {
+	state.stageTimers[i] = m.clock.AfterFunc(after, func() {
+		m.triggerStage(state, i)
+	})
+}
+
+// restartStages re-arms every stage except the last one relative to now.
+// The caller must hold m.mu.
+func (m *RequestLifecycleManager[T]) restartStages(state *requestState[T]) {
+	for i := 0; i < len(state.stages)-1; i++ {
+		if timer := state.stageTimers[i]; timer != nil {
+			timer.Stop()
+		}
+		m.scheduleStage(state, i, state.stages[i].After)
+	}
+}
+
+// triggerStage handles stage i firing for a staged request.
+// Intermediate stages only run the callback; the last stage also cleans the request up.
+func (m *RequestLifecycleManager[T]) triggerStage(state *requestState[T], i int) {
+	select {
+	case <-m.ctx.Done():
+		return
+	default:
+	}
+
+	stage := state.stages[i]
+	if i == len(state.stages)-1 {
+		if !m.cleanupRequest(state.id) {
+			return
+		}
+		defer m.recoverCallback(state.id)
+
+		m.notifyCancelled(state.id, fmt.Sprintf("request exceeded %s stage", stage.Name))
+		state.onStage(state.id, stage)
+		return
+	}
+
+	m.mu.Lock()
+	current, tracked := m.requests[state.id]
+	m.mu.Unlock()
+	if !tracked || current != state {
+		return
+	}
+
+	defer m.recoverCallback(state.id)
+	state.onStage(state.id, stage)
+}
@@ -0,0 +1,185 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testStages() []TimeoutStage {
+	return []TimeoutStage{
+		{Name: "warn", After: 5 * time.Second},
+		{Name: "cancel", After: 30 * time.Second},
+		{Name: "cleanup", After: 60 * time.Second},
+	}
+}
+
+func TestStartStagedRequestFiresStagesInOrder(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var sent []Notification
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithCancellationNotifier[int64](func(n Notification) error {
+			sent = append(sent, n)
+			return nil
+		}),
+	)
+	id := NewID(int64(1))
+
+	var fired []string
+	err := manager.StartStagedRequest(id, testStages(), func(_ ID[int64], stage TimeoutStage) {
+		fired = append(fired, stage.Name)
+	})
+	if err != nil {
+		t.Fatalf("StartStagedRequest failed: %v", err)
+	}
+
+	clock.Advance(30 * time.Second)
+	if len(fired) != 2 || fired[0] != "warn" || fired[1] != "cancel" {
+		t.Fatalf("Expected [warn cancel], got %v", fired)
+	}
+	if manager.Len() != 1 || len(sent) != 0 {
+		t.Fatalf("Expected request still tracked without cancellation, got len=%d sent=%d", manager.Len(), len(sent))
+	}
+
+	clock.Advance(30 * time.Second)
+	if len(fired) != 3 || fired[2] != "cleanup" {
+		t.Fatalf("Expected cleanup stage, got %v", fired)
+	}
+	if manager.Len() != 0 || clock.Pending() != 0 {
+		t.Errorf("Expected request cleaned up, got len=%d pending=%d", manager.Len(), clock.Pending())
+	}
+	if len(sent) != 1 || sent[0].GetMethod() != NotificationCancelled {
+		t.Errorf("Expected one cancellation notification, got %v", sent)
+	}
+}
+
+func TestResetTimeoutRestartsStagesButNotLast(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(2))
+
+	var fired []string
+	manager.StartStagedRequest(id, testStages(), func(_ ID[int64], stage TimeoutStage) {
+		fired = append(fired, stage.Name)
+	})
+
+	clock.Advance(6 * time.Second)
+	if err := manager.ResetTimeout(id); err != nil {
+		t.Fatalf("ResetTimeout failed: %v", err)
+	}
+
+	// warn fires again at 11s, cancel has moved to 36s, cleanup stays at 60s.
+	clock.Advance(29 * time.Second)
+	if len(fired) != 2 || fired[1] != "warn" {
+		t.Fatalf("Expected [warn warn], got %v", fired)
+	}
+	clock.Advance(25 * time.Second)
+	if len(fired) != 4 || fired[2] != "cancel" || fired[3] != "cleanup" {
+		t.Fatalf("Expected cancel then cleanup, got %v", fired)
+	}
+}
+
+func TestStartStagedRequestValidation(t *testing.T) {
+	manager := NewRequestLifecycleManager[int64](nil)
+	callback := func(ID[int64], TimeoutStage) {}
+
+	tests := []struct {
+		name     string
+		id       ID[int64]
+		stages   []TimeoutStage
+		callback func(ID[int64], TimeoutStage)
+		expected error
+	}{
+		{"NilCallback", NewID(int64(1)), testStages(), nil, ErrCallbackNil},
+		{"EmptyID", ID[int64]{}, testStages(), callback, ErrEmptyRequestID},
+		{"NoStages", NewID(int64(1)), nil, callback, ErrNoTimeoutStages},
+		{"NotPositive", NewID(int64(1)), []TimeoutStage{{Name: "now"}}, callback, ErrTimeoutStagesNotOrdered},
+		{"NotIncreasing", NewID(int64(1)), []TimeoutStage{{"a", time.Second}, {"b", time.Second}}, callback, ErrTimeoutStagesNotOrdered},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := manager.StartStagedRequest(test.id, test.stages, test.callback)
+			if !errors.Is(err, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, err)
+			}
+		})
+	}
+
+	// Validation failures must not burn the ID.
+	if err := manager.StartStagedRequest(NewID(int64(1)), testStages(), callback); err != nil {
+		t.Fatalf("Expected ID to be usable, got %v", err)
+	}
+	if err := manager.StartStagedRequest(NewID(int64(1)), testStages(), callback); !errors.Is(err, ErrDuplicateRequestID) {
+		t.Errorf("Expected ErrDuplicateRequestID, got %v", err)
+	}
+	manager.StopAll(true)
+}
+
+func TestStagedCallbackPanicIsReported(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var reported []error
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithErrorHandler[int64](func(_ ID[int64], err error) { reported = append(reported, err) }),
+	)
+
+	manager.StartStagedRequest(NewID(int64(3)), testStages(), func(ID[int64], TimeoutStage) {
+		panic("boom")
+	})
+
+	clock.Advance(time.Minute)
+	if len(reported) != 3 {
+		t.Errorf("Expected 3 reported panics, got %v", reported)
+	}
+	if manager.Len() != 0 {
+		t.Errorf("Expected request cleaned up, got %d", manager.Len())
+	}
+}
+
+func TestStagedNotifierPanicDoesNotSkipFinalStage(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var reported []error
+	manager := NewRequestLifecycleManager[int64](nil,
+		WithClock[int64](clock),
+		WithCancellationNotifier[int64](func(Notification) error { panic("notifier down") }),
+		WithErrorHandler[int64](func(_ ID[int64], err error) { reported = append(reported, err) }),
+	)
+
+	var fired []string
+	manager.StartStagedRequest(NewID(int64(5)), testStages(), func(_ ID[int64], stage TimeoutStage) {
+		fired = append(fired, stage.Name)
+	})
+
+	clock.Advance(time.Minute)
+	if len(fired) != 3 || fired[2] != "cleanup" {
+		t.Errorf("Expected every stage to fire, got %v", fired)
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected the notifier panic to be reported once, got %v", reported)
+	}
+}
+
+func TestUpdateCallbackRejectsStagedRequest(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	manager := NewRequestLifecycleManager[int64](nil, WithClock[int64](clock))
+	id := NewID(int64(4))
+
+	var fired []string
+	manager.StartStagedRequest(id, testStages(), func(_ ID[int64], stage TimeoutStage) {
+		fired = append(fired, stage.Name)
+	})
+
+	err := manager.UpdateCallback(id, func(ID[int64], TimeoutType) {
+		t.Error("Replacement callback must not be called")
+	})
+	if !errors.Is(err, ErrStagedRequest) {
+		t.Fatalf("Expected ErrStagedRequest, got %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	if len(fired) != 3 {
+		t.Errorf("Expected the original stage callback to keep firing, got %v", fired)
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 func TestNewRequestConstructsProperly(t *testing.T) {
-	Id := newID(1)
+	Id := NewID(1)
 	Method := "doSomething"
 	Params := map[string]interface{}{"x": 42}
 
@@ -37,7 +37,7 @@ func TestNewRequestConstructsProperly(t *testing.T) {
 }
 
 func TestSetID_ValidAndInvalid(t *testing.T) {
-	Req := NewRequest("method", nil, newID(1)).(*jsonRPCRequest[int])
+	Req := NewRequest("method", nil, NewID(1)).(*jsonRPCRequest[int])
 
 	Err := Req.SetID(100)
 	if Err != nil {
@@ -58,7 +58,7 @@ func TestValidate_ValidRequest(t *testing.T) {
 		JSONRPC: JSONRPCVersion,
 		Method:  "validMethod",
 		Params:  nil,
-		ID:      newID(42),
+		ID:      NewID(42),
 	}
 	Err := Req.validate()
 	if Err != nil {
@@ -70,7 +70,7 @@ func TestValidate_InvalidJSONRPCVersion(t *testing.T) {
 	Req := &jsonRPCRequest[int]{
 		JSONRPC: "1.0",
 		Method:  "validMethod",
-		ID:      newID(1),
+		ID:      NewID(1),
 	}
 	Err := Req.validate()
 	if Err == nil || Err.Error() != `invalid JSON-RPC version: expected "2.0", got "1.0"` {
@@ -82,7 +82,7 @@ func TestValidate_EmptyMethod(t *testing.T) {
 	Req := &jsonRPCRequest[int]{
 		JSONRPC: JSONRPCVersion,
 		Method:  "   ",
-		ID:      newID(1),
+		ID:      NewID(1),
 	}
 	Err := Req.validate()
 	if Err == nil || Err.Error() != "method name cannot be empty or whitespace" {
@@ -94,7 +94,7 @@ func TestValidate_EmptyID(t *testing.T) {
 	Req := &jsonRPCRequest[int]{
 		JSONRPC: JSONRPCVersion,
 		Method:  "test",
-		ID:      newID(0),
+		ID:      NewID(0),
 	}
 	Err := Req.validate()
 	if Err == nil || Err.Error() != "id must not be empty" {
@@ -106,7 +106,7 @@ func TestValidate_ReservedMethodPrefix(t *testing.T) {
 	Req := &jsonRPCRequest[int]{
 		JSONRPC: JSONRPCVersion,
 		Method:  "rpc.internal",
-		ID:      newID(1),
+		ID:      NewID(1),
 	}
 	Err := Req.validate()
 	if Err == nil || Err.Error() != `method names starting with 'rpc.' are reserved, got: "rpc.internal"` {
@@ -172,3 +172,64 @@ func TestUnmarshalJSONInvalidJSONStruct(t *testing.T) {
 		t.Errorf("Expected error on invalid JSON structure, got nil")
 	}
 }
+
+func TestUnmarshalJSONRequest_ZeroIDOptIn(t *testing.T) {
+	t.Parallel()
+	Data := `{"jsonrpc":"2.0","method":"ping","id":0}`
+
+	var IntReq jsonRPCRequest[int]
+	if Err := json.Unmarshal([]byte(Data), &IntReq); Err == nil {
+		t.Fatal("Expected zero ID to be rejected by default")
+	}
+
+	var Req jsonRPCRequest[ZeroInt]
+	if Err := json.Unmarshal([]byte(Data), &Req); Err != nil {
+		t.Errorf("Expected zero ID to be accepted, got %v", Err)
+	}
+	if Req.Method != "ping" || Req.ID.Value != 0 {
+		t.Errorf("Unexpected request: %+v", Req)
+	}
+
+	for _, Missing := range []string{
+		`{"jsonrpc":"2.0","method":"ping"}`,
+		`{"jsonrpc":"2.0","method":"ping","id":null}`,
+	} {
+		var MissingReq jsonRPCRequest[ZeroInt]
+		Err := json.Unmarshal([]byte(Missing), &MissingReq)
+		if Err == nil || Err.Error() != "id must not be empty" {
+			t.Errorf("Expected missing ID error for %s, got %v", Missing, Err)
+		}
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	type Target struct {
+		X int `json:"x"`
+	}
+
+	Sources := []interface{}{
+		map[string]interface{}{"x": 1},
+		json.RawMessage(`{"x":1}`),
+		struct {
+			X int `json:"x"`
+		}{X: 1},
+	}
+	for _, Source := range Sources {
+		var Out Target
+		if Err := DecodeParams(Source, &Out); Err != nil || Out.X != 1 {
+			t.Errorf("DecodeParams(%T) = %+v, %v", Source, Out, Err)
+		}
+	}
+
+	Out := Target{X: 5}
+	if Err := DecodeParams(nil, &Out); Err != nil || Out.X != 5 {
+		t.Errorf("Expected nil params to leave target untouched, got %+v, %v", Out, Err)
+	}
+
+	if Err := DecodeParams(make(chan int), &Out); Err == nil {
+		t.Error("Expected marshal error for unsupported params")
+	}
+	if Err := DecodeParams([]int{1}, &Out); Err == nil {
+		t.Error("Expected error for mismatched params shape")
+	}
+}
@@ -0,0 +1,61 @@
+package protocol
+
+import (
+	"io/fs"
+	"time"
+)
+
+// Resource is the description of a resource the server can read, as returned by resources/list.
+type Resource struct {
+	// URI uniquely identifies the resource.
+	URI string `json:"uri"`
+	// Name is a programmatic identifier of the resource.
+	Name string `json:"name"`
+	// Title is an optional human-friendly name for display.
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+	// Size is the raw content size in bytes, if known, so clients can decide whether to fetch it.
+	Size *int64 `json:"size,omitempty"`
+	// Annotations are optional hints for clients.
+	Annotations *Annotations `json:"annotations,omitempty"`
+	// Icons are optional images clients can display for the resource.
+	Icons []Icon `json:"icons,omitempty"`
+}
+
+// DisplayName returns Title if set, otherwise Name.
+func (r Resource) DisplayName() string {
+	return displayName(r.Title, r.Name)
+}
+
+// SetLastModified records the modification time in Annotations.LastModified as RFC 3339 UTC.
+func (r *Resource) SetLastModified(t time.Time) {
+	if r.Annotations == nil {
+		r.Annotations = &Annotations{}
+	}
+	r.Annotations.LastModified = t.UTC().Format(time.RFC3339)
+}
+
+// SetFileInfo fills Size and the last modification time from a file's metadata.
+// Directories and other non-regular files only get the modification time.
+//
+// Example:
+//
+//	info, err := os.Stat(path)
+//	if err == nil {
+//	    res.SetFileInfo(info)
+//	}
+func (r *Resource) SetFileInfo(info fs.FileInfo) {
+	if info.Mode().IsRegular() {
+		size := info.Size()
+		r.Size = &size
+	}
+	r.SetLastModified(info.ModTime())
+}
+
+// ListResourcesResult is the result of a resources/list request.
+type ListResourcesResult struct {
+	Resources []Resource `json:"resources"`
+	// NextCursor is set when more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,132 @@
+package protocol
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ResourceURIPolicy controls how resource URIs are validated and normalized,
+// so that differently encoded spellings of the same URI map to a single entry.
+//
+// Example:
+//
+//	policy := protocol.ResourceURIPolicy{AllowedSchemes: []string{"file", "https"}}
+//	uri, err := policy.Normalize("FILE:///tmp/report%7e.txt/") // file:///tmp/report~.txt
+type ResourceURIPolicy struct {
+	// AllowedSchemes restricts the accepted URI schemes. Empty allows any scheme.
+	AllowedSchemes []string
+	// KeepTrailingSlash keeps a trailing "/" on paths instead of removing it.
+	KeepTrailingSlash bool
+}
+
+// Normalize validates raw and returns its normalized form:
+//   - the URI must be absolute (have a scheme) and parse without errors;
+//   - the scheme must be allowed by the policy;
+//   - scheme and host are lowercased;
+//   - percent-encoded unreserved characters are decoded and other escapes use uppercase hex;
+//   - a trailing slash is removed from non-root paths unless KeepTrailingSlash is set.
+//
+// Returns a ValidationError for malformed or disallowed URIs.
+func (p ResourceURIPolicy) Normalize(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", NewValidationError("invalid resource URI %q: %v", raw, err)
+	}
+	if u.Scheme == "" {
+		return "", NewValidationError("invalid resource URI %q: scheme is required", raw)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if !p.schemeAllowed(u.Scheme) {
+		return "", NewValidationError("invalid resource URI %q: scheme %q is not allowed", raw, u.Scheme)
+	}
+	u.Host = strings.ToLower(u.Host)
+
+	if u.Opaque != "" {
+		u.Opaque = normalizePercentEncoding(u.Opaque)
+		return u.String(), nil
+	}
+
+	rawPath := normalizePercentEncoding(u.EscapedPath())
+	if !p.KeepTrailingSlash && len(rawPath) > 1 && strings.HasSuffix(rawPath, "/") {
+		rawPath = strings.TrimRight(rawPath, "/")
+		if rawPath == "" {
+			rawPath = "/"
+		}
+	}
+	if u.Path, err = url.PathUnescape(rawPath); err != nil {
+		return "", NewValidationError("invalid resource URI %q: %v", raw, err)
+	}
+	u.RawPath = rawPath
+	u.RawQuery = normalizePercentEncoding(u.RawQuery)
+	u.RawFragment = normalizePercentEncoding(u.EscapedFragment())
+	if u.Fragment, err = url.PathUnescape(u.RawFragment); err != nil {
+		return "", NewValidationError("invalid resource URI %q: %v", raw, err)
+	}
+
+	return u.String(), nil
+}
+
+func (p ResourceURIPolicy) schemeAllowed(scheme string) bool {
+	if len(p.AllowedSchemes) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedSchemes {
+		if strings.EqualFold(allowed, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeResourceURI normalizes raw with the default policy, which allows any scheme
+// and removes trailing slashes. See ResourceURIPolicy.Normalize.
+func NormalizeResourceURI(raw string) (string, error) {
+	return ResourceURIPolicy{}.Normalize(raw)
+}
+
+// normalizePercentEncoding decodes escapes of unreserved characters (RFC 3986, section 2.3)
+// and uppercases the hex digits of the remaining escapes. Invalid escapes are left untouched.
+func normalizePercentEncoding(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i+2 >= len(s) || !isHex(s[i+1]) || !isHex(s[i+2]) {
+			b.WriteByte(s[i])
+			continue
+		}
+		c := unhex(s[i+1])<<4 | unhex(s[i+2])
+		if isUnreserved(c) {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('%')
+			b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+		}
+		i += 2
+	}
+	return b.String()
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
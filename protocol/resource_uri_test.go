@@ -0,0 +1,76 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeResourceURI(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{"file:///tmp/a.txt", "file:///tmp/a.txt"},
+		{"FILE:///tmp/report%7e.txt", "file:///tmp/report~.txt"},
+		{"https://Example.COM/a%2fb/", "https://example.com/a%2Fb"},
+		{"https://example.com/dir///", "https://example.com/dir"},
+		{"https://example.com/", "https://example.com/"},
+		{"file:///", "file:///"},
+		{"https://example.com/a%20b?q=%7euser&x=%2f", "https://example.com/a%20b?q=~user&x=%2F"},
+		{"https://example.com/a#sec%7e1", "https://example.com/a#sec~1"},
+		{"urn:isbn:%7e0451450523", "urn:isbn:~0451450523"},
+		{"  custom://res/1  ", "custom://res/1"},
+	}
+
+	for _, test := range tests {
+		got, err := NormalizeResourceURI(test.raw)
+		if err != nil {
+			t.Errorf("NormalizeResourceURI(%q): unexpected error %v", test.raw, err)
+			continue
+		}
+		if got != test.expected {
+			t.Errorf("NormalizeResourceURI(%q): expected %q, got %q", test.raw, test.expected, got)
+		}
+	}
+}
+
+func TestNormalizeResourceURIEquivalentSpellings(t *testing.T) {
+	a, _ := NormalizeResourceURI("file:///tmp/%7Euser/notes/")
+	b, _ := NormalizeResourceURI("FILE:///tmp/~user/notes")
+	if a != b {
+		t.Errorf("Expected equivalent URIs to normalize identically, got %q and %q", a, b)
+	}
+}
+
+func TestResourceURIPolicyKeepTrailingSlash(t *testing.T) {
+	got, err := ResourceURIPolicy{KeepTrailingSlash: true}.Normalize("file:///tmp/dir/")
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if got != "file:///tmp/dir/" {
+		t.Errorf("Expected trailing slash to be kept, got %q", got)
+	}
+}
+
+func TestResourceURIPolicyRejectsInvalidURIs(t *testing.T) {
+	policy := ResourceURIPolicy{AllowedSchemes: []string{"file", "HTTPS"}}
+
+	if _, err := policy.Normalize("HTTPS://example.com/a"); err != nil {
+		t.Errorf("Expected scheme match to be case-insensitive, got %v", err)
+	}
+
+	invalid := []string{
+		"relative/path",
+		"",
+		"http://example.com/a",
+		"file:///tmp/%zz",
+		"https://exa mple.com/",
+		"file:///tmp/\x7f",
+	}
+	for _, raw := range invalid {
+		var vErr *ValidationError
+		if _, err := policy.Normalize(raw); !errors.As(err, &vErr) {
+			t.Errorf("Normalize(%q): expected ValidationError, got %v", raw, err)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package protocol
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
 )
 
 // jsonRPCResponse represents a JSON-RPC 2.0 / MCP-compliant response.
@@ -100,7 +103,7 @@ func NewRPCError(code int, message string, data interface{}) *RPCError {
 //
 // Example:
 //
-//	response := NewSuccessResponse(NewID("req-123"), &Result{...})
+//	response := NewResponse("req-123", &Result{...})
 //	if err := response.validate(); err != nil {
 //	    log.Fatalf("Invalid response: %v", err)
 //	}
@@ -109,7 +112,7 @@ func (r jsonRPCResponse[T]) validate() error {
 		return &ValidationError{Reason: fmt.Sprintf("invalid JSON-RPC version: expected %q, got %q", JSONRPCVersion, r.JSONRPC)}
 	}
 
-	if r.ID.isEmpty() {
+	if !r.ID.isValid() {
 		return &ValidationError{Reason: "response ID must not be empty"}
 	}
 
@@ -156,13 +159,19 @@ func (r *jsonRPCResponse[T]) UnmarshalJSON(data []byte) error {
 	type responseNoMethods jsonRPCResponse[T]
 	aux := &struct {
 		responseNoMethods
+		// ID shadows the embedded field so that a missing ID can be told apart from a zero one.
+		ID *ID[T] `json:"id"`
 	}{}
 
 	if err := json.Unmarshal(data, aux); err != nil {
 		return err
 	}
+	if aux.ID == nil {
+		return &ValidationError{Reason: "response ID must not be empty"}
+	}
 
 	temp := jsonRPCResponse[T](aux.responseNoMethods)
+	temp.ID = *aux.ID
 	if err := temp.validate(); err != nil {
 		return err
 	}
@@ -171,6 +180,111 @@ func (r *jsonRPCResponse[T]) UnmarshalJSON(data []byte) error {
 
 }
 
+// Pre-encoded envelope fragments written by MarshalJSON.
+var (
+	responsePrefix  = []byte(`{"jsonrpc":"2.0","id":`)
+	jsonrpcPrefix   = []byte(`{"jsonrpc":`)
+	idField         = []byte(`,"id":`)
+	resultField     = []byte(`,"result":`)
+	errorField      = []byte(`,"error":`)
+	jsonNull        = []byte("null")
+	maxPooledBuffer = 64 << 10
+)
+
+// responseBufferPool holds scratch buffers reused across MarshalJSON calls.
+var responseBufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// It produces the same output as the default encoding. Results that are already
+// json.RawMessage are copied verbatim into a pooled buffer together with pre-encoded
+// envelope fragments instead of being marshaled a second time. Every other response
+// uses the default encoding, at the cost of the one copy json.Marshal makes of the
+// bytes a Marshaler returns.
+//
+// Example:
+//
+//	resp := protocol.NewResponse("req-1", json.RawMessage(`{"tools":[]}`))
+//	data, err := json.Marshal(resp)
+func (r *jsonRPCResponse[T]) MarshalJSON() ([]byte, error) {
+	raw, ok := r.Result.(json.RawMessage)
+	if !ok {
+		type responseNoMethods jsonRPCResponse[T]
+		return json.Marshal((*responseNoMethods)(r))
+	}
+
+	buf := responseBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			responseBufferPool.Put(buf)
+		}
+	}()
+
+	if r.JSONRPC == JSONRPCVersion {
+		buf.Write(responsePrefix)
+	} else {
+		buf.Write(jsonrpcPrefix)
+		if err := writeJSONValue(buf, r.JSONRPC); err != nil {
+			return nil, err
+		}
+		buf.Write(idField)
+	}
+
+	if err := writeIDValue(buf, r.ID.Value); err != nil {
+		return nil, err
+	}
+
+	buf.Write(resultField)
+	if len(raw) == 0 {
+		buf.Write(jsonNull)
+	} else {
+		buf.Write(raw)
+	}
+
+	if r.Error != nil {
+		buf.Write(errorField)
+		if err := writeJSONValue(buf, r.Error); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	out := make([]byte, buf.Len())
+	copy(out, buf.Bytes())
+	return out, nil
+}
+
+// writeIDValue encodes an ID value into buf, avoiding reflection for plain integers.
+func writeIDValue[T IDConstraint](buf *bytes.Buffer, value T) error {
+	switch v := any(value).(type) {
+	case int:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(v), 10))
+		return nil
+	case int64:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), v, 10))
+		return nil
+	case ZeroInt:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(v), 10))
+		return nil
+	default:
+		return writeJSONValue(buf, value)
+	}
+}
+
+// writeJSONValue encodes v into buf the way json.Marshal does.
+func writeJSONValue(buf *bytes.Buffer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
 func (r *jsonRPCResponse[T]) GetID() any {
 	return r.ID.Value
 }
@@ -226,3 +340,116 @@ func NewResponse[T IDConstraint](id T, result interface{}) Response {
 		Result:  result,
 	}
 }
+
+// jsonRPCNullIDResponse is an error response whose ID is always JSON null.
+//
+// JSON-RPC 2.0 requires "id": null when the request ID could not be determined,
+// i.e. for Parse error and Invalid Request failures. ID[T] cannot represent null,
+// so these responses use a dedicated type instead of jsonRPCResponse.
+type jsonRPCNullIDResponse struct {
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID is always nil and serialized as null.
+	ID interface{} `json:"id"`
+
+	Error *RPCError `json:"error"`
+}
+
+// validate checks that the null-ID response carries a well-formed error.
+func (r jsonRPCNullIDResponse) validate() error {
+	if r.JSONRPC != JSONRPCVersion {
+		return &ValidationError{Reason: fmt.Sprintf("invalid JSON-RPC version: expected %q, got %q", JSONRPCVersion, r.JSONRPC)}
+	}
+	if r.ID != nil {
+		return &ValidationError{Reason: "null-ID response must have a null ID"}
+	}
+	if r.Error == nil {
+		return &ValidationError{Reason: "null-ID response MUST contain an error"}
+	}
+	if r.Error.Message == "" {
+		return &ValidationError{Reason: "error must contain non-empty message"}
+	}
+	return nil
+}
+
+// UnmarshalJSON deserializes and validates a null-ID error response.
+func (r *jsonRPCNullIDResponse) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return ErrEmptyJSONData
+	}
+
+	type responseNoMethods jsonRPCNullIDResponse
+	aux := &struct {
+		responseNoMethods
+	}{}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	temp := jsonRPCNullIDResponse(aux.responseNoMethods)
+	if err := temp.validate(); err != nil {
+		return err
+	}
+	*r = temp
+	return nil
+}
+
+func (r *jsonRPCNullIDResponse) GetID() any {
+	return nil
+}
+
+func (r *jsonRPCNullIDResponse) SetID(v any) error {
+	return NewValidationError("null-ID response cannot be assigned an ID, got %T", v)
+}
+
+func (r *jsonRPCNullIDResponse) GetResult() interface{} {
+	return nil
+}
+
+func (r *jsonRPCNullIDResponse) SetResult(v interface{}) error {
+	return &ValidationError{Reason: "null-ID response cannot carry a result"}
+}
+
+func (r *jsonRPCNullIDResponse) GetError() *RPCError {
+	return r.Error
+}
+
+func (r *jsonRPCNullIDResponse) SetError(err *RPCError) {
+	r.Error = err
+}
+
+func (r *jsonRPCNullIDResponse) HasResult() bool {
+	return false
+}
+
+func (r *jsonRPCNullIDResponse) HasError() bool {
+	return r.Error != nil
+}
+
+// NewNullIDErrorResponse creates an error response with "id": null.
+//
+// Use it only when the request ID could not be determined, such as for
+// ParseError or InvalidRequest failures; otherwise the response must echo the request ID.
+//
+// Example:
+//
+//	resp := protocol.NewNullIDErrorResponse(protocol.NewRPCError(protocol.InvalidRequest, "Invalid Request", nil))
+//	data, _ := json.Marshal(resp) // {"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}
+func NewNullIDErrorResponse(err *RPCError) Response {
+	return &jsonRPCNullIDResponse{
+		JSONRPC: JSONRPCVersion,
+		Error:   err,
+	}
+}
+
+// NewParseErrorResponse creates the null-ID response sent when inbound JSON cannot be parsed.
+//
+// Example:
+//
+//	if err := json.Unmarshal(body, &msg); err != nil {
+//	    resp := protocol.NewParseErrorResponse(err.Error())
+//	}
+func NewParseErrorResponse(data interface{}) Response {
+	return NewNullIDErrorResponse(NewRPCError(ParseError, "Parse error", data))
+}
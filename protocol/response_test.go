@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -577,3 +578,247 @@ func TestRPCErrorCreationAndConversion(t *testing.T) {
 		t.Errorf("Expected data[\"code\"] == 123, got %v", dataMap["code"])
 	}
 }
+
+// Test that the MarshalJSON fast path matches the default encoding
+func TestResponseMarshalJSONMatchesDefaultEncoding(t *testing.T) {
+	type plainResponse[T IDConstraint] struct {
+		JSONRPC string      `json:"jsonrpc"`
+		ID      T           `json:"id"`
+		Result  interface{} `json:"result,omitempty"`
+		Error   *RPCError   `json:"error,omitempty"`
+	}
+
+	rpcErr := NewRPCError(MethodNotFound, "Method <not> found", map[string]string{"method": "x"})
+	stringCases := []jsonRPCResponse[string]{
+		{JSONRPC: JSONRPCVersion, ID: ID[string]{Value: "req-1"}, Result: map[string]int{"b": 2, "a": 1}},
+		{JSONRPC: JSONRPCVersion, ID: ID[string]{Value: "quote\"and sep"}, Error: rpcErr},
+		{JSONRPC: "1.0", ID: ID[string]{Value: "old"}, Result: []string{"<html>"}},
+		{JSONRPC: JSONRPCVersion, ID: ID[string]{Value: "both"}, Result: 1, Error: rpcErr},
+	}
+	for _, resp := range stringCases {
+		expected, _ := json.Marshal(plainResponse[string]{resp.JSONRPC, resp.ID.Value, resp.Result, resp.Error})
+		data, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatalf("Failed to marshal response: %v", err)
+		}
+		if string(data) != string(expected) {
+			t.Errorf("Expected JSON: %s, got: %s", expected, data)
+		}
+	}
+
+	intResp := jsonRPCResponse[int64]{JSONRPC: JSONRPCVersion, ID: ID[int64]{Value: -42}, Result: struct{}{}}
+	expected, _ := json.Marshal(plainResponse[int64]{intResp.JSONRPC, intResp.ID.Value, intResp.Result, intResp.Error})
+	data, err := json.Marshal(intResp)
+	if err != nil {
+		t.Fatalf("Failed to marshal int response: %v", err)
+	}
+	if string(data) != string(expected) {
+		t.Errorf("Expected JSON: %s, got: %s", expected, data)
+	}
+}
+
+// Test that raw JSON results are written without re-encoding
+func TestResponseMarshalJSONRawMessageResult(t *testing.T) {
+	resp := NewResponse(int64(7), json.RawMessage(`{"tools":[]}`))
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	expected := `{"jsonrpc":"2.0","id":7,"result":{"tools":[]}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON: %s, got: %s", expected, string(data))
+	}
+
+	resp = NewResponse(int64(8), json.RawMessage{})
+	data, err = json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	expected = `{"jsonrpc":"2.0","id":8,"result":null}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON: %s, got: %s", expected, string(data))
+	}
+}
+
+// Test that unsupported result values surface a marshaling error
+func TestResponseMarshalJSONUnsupportedResult(t *testing.T) {
+	resp := NewResponse("bad", make(chan int))
+	if _, err := json.Marshal(resp); err == nil {
+		t.Error("Expected error when marshaling unsupported result type")
+	}
+}
+
+// Test that raw results allocate less than the default encoding and that other
+// results allocate at most the one extra copy json.Marshal makes of Marshaler output
+func TestResponseMarshalJSONAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful with the race detector")
+	}
+	results := map[string]interface{}{
+		"Raw":    json.RawMessage(`{"content":[{"type":"text","text":"hello"}]}`),
+		"Object": map[string]interface{}{"content": []map[string]string{{"type": "text", "text": "hello"}}},
+	}
+	for name, result := range results {
+		resp := NewResponse(int64(1), result).(*jsonRPCResponse[int64])
+		custom := testing.AllocsPerRun(100, func() { json.Marshal(resp) })
+		baseline := testing.AllocsPerRun(100, func() { json.Marshal((*defaultEncodedResponse)(resp)) })
+
+		if name == "Raw" && custom >= baseline {
+			t.Errorf("%s: expected fewer than %.0f allocations, got %.0f", name, baseline, custom)
+		}
+		if custom > baseline+1 {
+			t.Errorf("%s: expected at most %.0f allocations, got %.0f", name, baseline+1, custom)
+		}
+	}
+}
+
+// defaultEncodedResponse has the fields of jsonRPCResponse without its MarshalJSON
+// method, so benchmarks can compare against the default encoding.
+type defaultEncodedResponse jsonRPCResponse[int64]
+
+func BenchmarkResponseMarshalJSON(b *testing.B) {
+	result := map[string]interface{}{"content": []map[string]string{{"type": "text", "text": "hello"}}}
+	benchmarkResponseMarshal(b, result)
+}
+
+func BenchmarkResponseMarshalJSONRawResult(b *testing.B) {
+	benchmarkResponseMarshal(b, json.RawMessage(`{"content":[{"type":"text","text":"hello"}]}`))
+}
+
+// benchmarkResponseMarshal compares MarshalJSON against the default encoding of the same response.
+func benchmarkResponseMarshal(b *testing.B, result interface{}) {
+	resp := NewResponse(int64(1), result).(*jsonRPCResponse[int64])
+
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Default", func(b *testing.B) {
+		baseline := (*defaultEncodedResponse)(resp)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(baseline); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// Test that null-ID error responses serialize with "id": null
+func TestNewNullIDErrorResponse(t *testing.T) {
+	resp := NewNullIDErrorResponse(NewRPCError(InvalidRequest, "Invalid Request", nil))
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	expected := `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON: %s, got: %s", expected, string(data))
+	}
+
+	if resp.GetID() != nil {
+		t.Errorf("Expected nil ID, got %v", resp.GetID())
+	}
+	if resp.HasResult() || resp.GetResult() != nil {
+		t.Error("Expected no result")
+	}
+	if !resp.HasError() || resp.GetError().Code != InvalidRequest {
+		t.Errorf("Expected InvalidRequest error, got %v", resp.GetError())
+	}
+	if err := ValidateMessage(resp); err != nil {
+		t.Errorf("Expected response to be valid, got %v", err)
+	}
+}
+
+func TestNewParseErrorResponse(t *testing.T) {
+	resp := NewParseErrorResponse("unexpected end of JSON input")
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal response: %v", err)
+	}
+	expected := `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error","data":"unexpected end of JSON input"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON: %s, got: %s", expected, string(data))
+	}
+}
+
+func TestNullIDResponseRejectsIDAndResult(t *testing.T) {
+	resp := NewNullIDErrorResponse(NewRPCError(ParseError, "Parse error", nil))
+
+	var vErr *ValidationError
+	if err := resp.SetID("req-1"); !errors.As(err, &vErr) {
+		t.Errorf("Expected ValidationError from SetID, got %v", err)
+	}
+	if err := resp.SetResult("ok"); !errors.As(err, &vErr) {
+		t.Errorf("Expected ValidationError from SetResult, got %v", err)
+	}
+
+	resp.SetError(nil)
+	if resp.HasError() {
+		t.Error("Expected HasError to be false after clearing error")
+	}
+	if err := ValidateMessage(resp); err == nil {
+		t.Error("Expected validation error for null-ID response without error")
+	}
+}
+
+func TestNullIDResponseUnmarshalJSON(t *testing.T) {
+	var resp jsonRPCNullIDResponse
+	err := json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"Parse error"}}`), &resp)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if resp.Error == nil || resp.Error.Code != ParseError {
+		t.Errorf("Expected ParseError, got %v", resp.Error)
+	}
+
+	invalid := []string{
+		`{"jsonrpc":"2.0","id":1,"error":{"code":-32700,"message":"Parse error"}}`,
+		`{"jsonrpc":"2.0","id":null}`,
+		`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":""}}`,
+		`{"jsonrpc":"1.0","id":null,"error":{"code":-32700,"message":"Parse error"}}`,
+		`{"jsonrpc":`,
+	}
+	for _, data := range invalid {
+		var r jsonRPCNullIDResponse
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+
+	if err := resp.UnmarshalJSON(nil); err != ErrEmptyJSONData {
+		t.Errorf("Expected ErrEmptyJSONData, got %v", err)
+	}
+}
+
+// Test that zero IDs in responses are accepted only after opting in
+func TestUnmarshalJSONResponseZeroIDOptIn(t *testing.T) {
+	t.Parallel()
+	data := `{"jsonrpc":"2.0","id":0,"result":"ok"}`
+
+	var intResp jsonRPCResponse[int64]
+	if err := json.Unmarshal([]byte(data), &intResp); err == nil {
+		t.Fatal("Expected zero ID to be rejected by default")
+	}
+
+	var resp jsonRPCResponse[ZeroInt]
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Errorf("Expected zero ID to be accepted, got %v", err)
+	}
+	encoded, err := json.Marshal(resp)
+	if err != nil || string(encoded) != data {
+		t.Errorf("Expected %s after round trip, got %s (%v)", data, encoded, err)
+	}
+
+	var missing jsonRPCResponse[ZeroInt]
+	err = json.Unmarshal([]byte(`{"jsonrpc":"2.0","result":"ok"}`), &missing)
+	if err == nil || err.Error() != "response ID must not be empty" {
+		t.Errorf("Expected missing ID error, got %v", err)
+	}
+}
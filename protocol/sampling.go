@@ -0,0 +1,174 @@
+package protocol
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SamplingMessage is a message in a sampling/createMessage request or result.
+type SamplingMessage struct {
+	Role    Role    `json:"role"`
+	Content Content `json:"content"`
+}
+
+// UnmarshalJSON decodes the message, choosing the content block type from its "type" field.
+func (m *SamplingMessage) UnmarshalJSON(data []byte) error {
+	role, content, err := unmarshalMessage(data)
+	if err != nil {
+		return err
+	}
+	*m = SamplingMessage{Role: role, Content: content}
+	return nil
+}
+
+// unmarshalMessage decodes the role and single content block shared by prompt and sampling messages.
+func unmarshalMessage(data []byte) (Role, Content, error) {
+	var aux struct {
+		Role    Role            `json:"role"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return "", nil, err
+	}
+	content, err := UnmarshalContent(aux.Content)
+	if err != nil {
+		return "", nil, err
+	}
+	return aux.Role, content, nil
+}
+
+// ModelHint suggests a model to use for sampling.
+type ModelHint struct {
+	// Name is a full or partial model name, e.g. "claude-3-5-sonnet" or "sonnet".
+	// Clients should treat it as a substring match.
+	Name string `json:"name,omitempty"`
+}
+
+// ModelPreferences are the server's preferences for model selection in a sampling request.
+// Priorities range from 0 (not important) to 1 (most important).
+type ModelPreferences struct {
+	// Hints are evaluated in order; the first match wins.
+	Hints                []ModelHint `json:"hints,omitempty"`
+	CostPriority         *float64    `json:"costPriority,omitempty"`
+	SpeedPriority        *float64    `json:"speedPriority,omitempty"`
+	IntelligencePriority *float64    `json:"intelligencePriority,omitempty"`
+}
+
+// validate checks that all set priorities are within [0, 1]. NaN is rejected.
+func (p ModelPreferences) validate() error {
+	priorities := []struct {
+		name  string
+		value *float64
+	}{
+		{"costPriority", p.CostPriority},
+		{"speedPriority", p.SpeedPriority},
+		{"intelligencePriority", p.IntelligencePriority},
+	}
+	for _, priority := range priorities {
+		if priority.value != nil && !(*priority.value >= 0 && *priority.value <= 1) {
+			return NewValidationError("%s must be between 0 and 1, got %v", priority.name, *priority.value)
+		}
+	}
+	return nil
+}
+
+// ModelPreferencesBuilder assembles validated ModelPreferences.
+//
+// Example:
+//
+//	prefs, err := protocol.NewModelPreferences().
+//	    Hint("claude-3-5-sonnet").
+//	    Hint("sonnet").
+//	    IntelligencePriority(0.8).
+//	    SpeedPriority(0.3).
+//	    Build()
+type ModelPreferencesBuilder struct {
+	prefs ModelPreferences
+}
+
+// NewModelPreferences starts building model preferences.
+func NewModelPreferences() *ModelPreferencesBuilder {
+	return &ModelPreferencesBuilder{}
+}
+
+// Hint appends a model name hint. Hints are evaluated in the order they are added.
+func (b *ModelPreferencesBuilder) Hint(name string) *ModelPreferencesBuilder {
+	b.prefs.Hints = append(b.prefs.Hints, ModelHint{Name: name})
+	return b
+}
+
+// CostPriority sets how much to prioritize cost when selecting a model.
+func (b *ModelPreferencesBuilder) CostPriority(priority float64) *ModelPreferencesBuilder {
+	b.prefs.CostPriority = &priority
+	return b
+}
+
+// SpeedPriority sets how much to prioritize sampling speed (latency) when selecting a model.
+func (b *ModelPreferencesBuilder) SpeedPriority(priority float64) *ModelPreferencesBuilder {
+	b.prefs.SpeedPriority = &priority
+	return b
+}
+
+// IntelligencePriority sets how much to prioritize intelligence and capabilities when selecting a model.
+func (b *ModelPreferencesBuilder) IntelligencePriority(priority float64) *ModelPreferencesBuilder {
+	b.prefs.IntelligencePriority = &priority
+	return b
+}
+
+// Build returns the preferences, or a ValidationError if a priority is outside [0, 1].
+func (b *ModelPreferencesBuilder) Build() (*ModelPreferences, error) {
+	if err := b.prefs.validate(); err != nil {
+		return nil, err
+	}
+	prefs := b.prefs
+	prefs.Hints = append([]ModelHint(nil), b.prefs.Hints...)
+	return &prefs, nil
+}
+
+// ModelSelector maps a server's model preferences to a model the client can actually use.
+// Clients call it when fulfilling sampling/createMessage.
+type ModelSelector interface {
+	SelectModel(prefs *ModelPreferences) (string, error)
+}
+
+// HintModelSelector selects the first available model matching a hint.
+//
+// Hints are checked in order and match any available model that contains the
+// hint name as a case-insensitive substring. If nothing matches, Fallback is used.
+//
+// Example:
+//
+//	selector := protocol.HintModelSelector{
+//	    Models:   []string{"claude-3-5-sonnet-20241022", "claude-3-haiku-20240307"},
+//	    Fallback: "claude-3-haiku-20240307",
+//	}
+//	model, err := selector.SelectModel(params.ModelPreferences)
+type HintModelSelector struct {
+	// Models are the model identifiers available to the client.
+	Models []string
+	// Fallback is returned when no hint matches. It may be empty.
+	Fallback string
+}
+
+// SelectModel implements ModelSelector.
+//
+// Returns ErrNoModelAvailable if no hint matches and Fallback is empty.
+func (s HintModelSelector) SelectModel(prefs *ModelPreferences) (string, error) {
+	if prefs != nil {
+		for _, hint := range prefs.Hints {
+			name := strings.ToLower(strings.TrimSpace(hint.Name))
+			if name == "" {
+				continue
+			}
+			for _, model := range s.Models {
+				if strings.Contains(strings.ToLower(model), name) {
+					return model, nil
+				}
+			}
+		}
+	}
+	if s.Fallback == "" {
+		return "", ErrNoModelAvailable
+	}
+	return s.Fallback, nil
+}
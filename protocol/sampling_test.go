@@ -0,0 +1,89 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestModelPreferencesBuilder(t *testing.T) {
+	prefs, err := NewModelPreferences().
+		Hint("claude-3-5-sonnet").
+		Hint("sonnet").
+		CostPriority(0).
+		SpeedPriority(0.3).
+		IntelligencePriority(1).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"hints":[{"name":"claude-3-5-sonnet"},{"name":"sonnet"}],"costPriority":0,"speedPriority":0.3,"intelligencePriority":1}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+}
+
+func TestModelPreferencesBuilderIsReusable(t *testing.T) {
+	builder := NewModelPreferences().Hint("a")
+	first, _ := builder.Build()
+	builder.Hint("b")
+	if len(first.Hints) != 1 {
+		t.Errorf("Expected built preferences to be unaffected by later hints, got %v", first.Hints)
+	}
+}
+
+func TestModelPreferencesBuilderRejectsOutOfRange(t *testing.T) {
+	builders := []*ModelPreferencesBuilder{
+		NewModelPreferences().CostPriority(-0.1),
+		NewModelPreferences().SpeedPriority(1.5),
+		NewModelPreferences().IntelligencePriority(2),
+		NewModelPreferences().CostPriority(math.NaN()),
+		NewModelPreferences().SpeedPriority(math.Inf(1)),
+	}
+
+	for _, builder := range builders {
+		var vErr *ValidationError
+		if _, err := builder.Build(); !errors.As(err, &vErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	}
+}
+
+func TestHintModelSelector(t *testing.T) {
+	selector := HintModelSelector{
+		Models:   []string{"claude-3-5-sonnet-20241022", "claude-3-haiku-20240307"},
+		Fallback: "claude-3-haiku-20240307",
+	}
+
+	tests := []struct {
+		name     string
+		prefs    *ModelPreferences
+		expected string
+	}{
+		{"NilPreferences", nil, "claude-3-haiku-20240307"},
+		{"FirstHintWins", &ModelPreferences{Hints: []ModelHint{{Name: "Sonnet"}, {Name: "haiku"}}}, "claude-3-5-sonnet-20241022"},
+		{"SkipsUnknownHints", &ModelPreferences{Hints: []ModelHint{{Name: "gpt-4o"}, {Name: ""}, {Name: "haiku"}}}, "claude-3-haiku-20240307"},
+		{"FallbackWhenNoMatch", &ModelPreferences{Hints: []ModelHint{{Name: "gemini"}}}, "claude-3-haiku-20240307"},
+	}
+
+	for _, test := range tests {
+		model, err := selector.SelectModel(test.prefs)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		}
+		if model != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, model)
+		}
+	}
+
+	var _ ModelSelector = selector
+	if _, err := (HintModelSelector{}).SelectModel(nil); err != ErrNoModelAvailable {
+		t.Errorf("Expected ErrNoModelAvailable, got %v", err)
+	}
+}
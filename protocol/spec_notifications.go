@@ -0,0 +1,132 @@
+package protocol
+
+// Notification method names defined by the MCP specification.
+const (
+	NotificationInitialized         = "notifications/initialized"
+	NotificationCancelled           = "notifications/cancelled"
+	NotificationProgress            = "notifications/progress"
+	NotificationMessage             = "notifications/message"
+	NotificationResourceUpdated     = "notifications/resources/updated"
+	NotificationResourceListChanged = "notifications/resources/list_changed"
+	NotificationToolListChanged     = "notifications/tools/list_changed"
+	NotificationPromptListChanged   = "notifications/prompts/list_changed"
+	NotificationRootsListChanged    = "notifications/roots/list_changed"
+)
+
+// LoggingLevel is the severity of a log message, following RFC 5424 syslog levels.
+type LoggingLevel string
+
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// ProgressNotificationParams are the params of a notifications/progress message.
+type ProgressNotificationParams struct {
+	// ProgressToken is the token from the _meta of the request being reported on.
+	ProgressToken interface{} `json:"progressToken"`
+	// Progress so far. It MUST increase with each notification.
+	Progress float64 `json:"progress"`
+	// Total is the total amount of work, if known. Zero means unknown and is omitted.
+	Total float64 `json:"total,omitempty"`
+	// Message is an optional human-readable description of the current progress.
+	Message string `json:"message,omitempty"`
+}
+
+// CancelledNotificationParams are the params of a notifications/cancelled message.
+type CancelledNotificationParams struct {
+	// RequestID is the ID of the request to cancel.
+	RequestID interface{} `json:"requestId"`
+	// Reason optionally describes why the request was cancelled.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ResourceUpdatedNotificationParams are the params of a notifications/resources/updated message.
+type ResourceUpdatedNotificationParams struct {
+	// URI of the resource that changed.
+	URI string `json:"uri"`
+}
+
+// LoggingMessageNotificationParams are the params of a notifications/message message.
+type LoggingMessageNotificationParams struct {
+	Level LoggingLevel `json:"level"`
+	// Logger optionally names the logger that issued the message.
+	Logger string `json:"logger,omitempty"`
+	// Data is any JSON-serializable value to log.
+	Data interface{} `json:"data"`
+}
+
+// NewInitializedNotification creates the notification a client sends once initialization has finished.
+func NewInitializedNotification() Notification {
+	return NewNotification(NotificationInitialized, nil)
+}
+
+// NewProgressNotification creates a progress notification for the request that sent progressToken.
+// Pass a zero total when the total amount of work is unknown.
+//
+// Example:
+//
+//	n := protocol.NewProgressNotification("progress-1", 50, 100)
+func NewProgressNotification(progressToken interface{}, progress, total float64) Notification {
+	return NewNotification(NotificationProgress, ProgressNotificationParams{
+		ProgressToken: progressToken,
+		Progress:      progress,
+		Total:         total,
+	})
+}
+
+// NewCancelledNotification creates a notification asking the receiver to cancel a previously sent request.
+//
+// Example:
+//
+//	n := protocol.NewCancelledNotification(req.ID, "user aborted")
+func NewCancelledNotification[T IDConstraint](id ID[T], reason string) Notification {
+	return NewNotification(NotificationCancelled, CancelledNotificationParams{
+		RequestID: id,
+		Reason:    reason,
+	})
+}
+
+// NewResourceUpdatedNotification tells a subscribed client that the resource at uri has changed.
+func NewResourceUpdatedNotification(uri string) Notification {
+	return NewNotification(NotificationResourceUpdated, ResourceUpdatedNotificationParams{URI: uri})
+}
+
+// NewLogMessageNotification creates a log message notification sent from server to client.
+//
+// Example:
+//
+//	n := protocol.NewLogMessageNotification(protocol.LoggingLevelError, "db", map[string]any{"error": "timeout"})
+func NewLogMessageNotification(level LoggingLevel, logger string, data interface{}) Notification {
+	return NewNotification(NotificationMessage, LoggingMessageNotificationParams{
+		Level:  level,
+		Logger: logger,
+		Data:   data,
+	})
+}
+
+// NewResourceListChangedNotification tells the client that the list of available resources has changed.
+func NewResourceListChangedNotification() Notification {
+	return NewNotification(NotificationResourceListChanged, nil)
+}
+
+// NewToolListChangedNotification tells the client that the list of available tools has changed.
+func NewToolListChangedNotification() Notification {
+	return NewNotification(NotificationToolListChanged, nil)
+}
+
+// NewPromptListChangedNotification tells the client that the list of available prompts has changed.
+func NewPromptListChangedNotification() Notification {
+	return NewNotification(NotificationPromptListChanged, nil)
+}
+
+// NewRootsListChangedNotification tells the server that the client's list of roots has changed.
+func NewRootsListChangedNotification() Notification {
+	return NewNotification(NotificationRootsListChanged, nil)
+}
@@ -0,0 +1,85 @@
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpecNotificationsMarshal(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification Notification
+		expected     string
+	}{
+		{
+			"Initialized",
+			NewInitializedNotification(),
+			`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		},
+		{
+			"Progress",
+			NewProgressNotification("progress-1", 50, 100),
+			`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":"progress-1","progress":50,"total":100}}`,
+		},
+		{
+			"ProgressUnknownTotal",
+			NewProgressNotification(3, 0.5, 0),
+			`{"jsonrpc":"2.0","method":"notifications/progress","params":{"progressToken":3,"progress":0.5}}`,
+		},
+		{
+			"Cancelled",
+			NewCancelledNotification(NewID(int64(42)), "timed out"),
+			`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":42,"reason":"timed out"}}`,
+		},
+		{
+			"CancelledWithoutReason",
+			NewCancelledNotification(NewID("req-1"), ""),
+			`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"req-1"}}`,
+		},
+		{
+			"ResourceUpdated",
+			NewResourceUpdatedNotification("file:///tmp/a.txt"),
+			`{"jsonrpc":"2.0","method":"notifications/resources/updated","params":{"uri":"file:///tmp/a.txt"}}`,
+		},
+		{
+			"LogMessage",
+			NewLogMessageNotification(LoggingLevelWarning, "db", map[string]string{"error": "slow"}),
+			`{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"warning","logger":"db","data":{"error":"slow"}}}`,
+		},
+		{
+			"ResourceListChanged",
+			NewResourceListChangedNotification(),
+			`{"jsonrpc":"2.0","method":"notifications/resources/list_changed"}`,
+		},
+		{
+			"ToolListChanged",
+			NewToolListChangedNotification(),
+			`{"jsonrpc":"2.0","method":"notifications/tools/list_changed"}`,
+		},
+		{
+			"PromptListChanged",
+			NewPromptListChangedNotification(),
+			`{"jsonrpc":"2.0","method":"notifications/prompts/list_changed"}`,
+		},
+		{
+			"RootsListChanged",
+			NewRootsListChangedNotification(),
+			`{"jsonrpc":"2.0","method":"notifications/roots/list_changed"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateMessage(test.notification); err != nil {
+				t.Fatalf("Expected valid notification, got %v", err)
+			}
+			data, err := json.Marshal(test.notification)
+			if err != nil {
+				t.Fatalf("Failed to marshal notification: %v", err)
+			}
+			if string(data) != test.expected {
+				t.Errorf("Expected JSON: %s, got: %s", test.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package protocol
+
+import "errors"
+
+// MethodToolsCall is the method of the request that invokes a tool.
+const MethodToolsCall = "tools/call"
+
+// ToolAnnotations are hints describing a tool's behavior.
+// Clients MUST treat them as untrusted unless the server is trusted.
+type ToolAnnotations struct {
+	// Title is a human-readable title for the tool.
+	Title string `json:"title,omitempty"`
+	// ReadOnlyHint indicates the tool does not modify its environment.
+	ReadOnlyHint *bool `json:"readOnlyHint,omitempty"`
+	// DestructiveHint indicates the tool may perform destructive updates.
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+	// IdempotentHint indicates repeated calls with the same arguments have no additional effect.
+	IdempotentHint *bool `json:"idempotentHint,omitempty"`
+	// OpenWorldHint indicates the tool may interact with external entities.
+	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
+}
+
+// Tool is the definition of a tool the client can call, as returned by tools/list.
+type Tool struct {
+	// Name uniquely identifies the tool.
+	Name string `json:"name"`
+	// Title is an optional human-friendly name for display.
+	Title string `json:"title,omitempty"`
+	// Description tells the model what the tool does.
+	Description string `json:"description,omitempty"`
+	// InputSchema is a JSON Schema object describing the tool's arguments.
+	InputSchema interface{} `json:"inputSchema"`
+	// Annotations are optional hints about the tool's behavior.
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+	// Icons are optional images clients can display for the tool.
+	Icons []Icon `json:"icons,omitempty"`
+}
+
+// DisplayName returns the name clients should show for the tool:
+// Title, then Annotations.Title, then Name.
+func (t Tool) DisplayName() string {
+	if t.Title == "" && t.Annotations != nil {
+		return displayName(t.Annotations.Title, t.Name)
+	}
+	return displayName(t.Title, t.Name)
+}
+
+// IsReadOnly reports whether the tool is annotated as not modifying its environment.
+// Tools without a readOnlyHint are not considered read-only.
+func (t Tool) IsReadOnly() bool {
+	return t.Annotations != nil && t.Annotations.ReadOnlyHint != nil && *t.Annotations.ReadOnlyHint
+}
+
+// IsDestructive reports whether the tool may perform destructive updates.
+// Following the specification, a tool that is not read-only is destructive
+// unless it explicitly sets destructiveHint to false.
+func (t Tool) IsDestructive() bool {
+	if t.IsReadOnly() {
+		return false
+	}
+	if t.Annotations != nil && t.Annotations.DestructiveHint != nil {
+		return *t.Annotations.DestructiveHint
+	}
+	return true
+}
+
+// FilterReadOnlyTools returns only the tools annotated as read-only, preserving order.
+// Servers running in read-only mode can use it to build their tools/list response:
+// it keeps exactly the tools that calls are not rejected for with NewReadOnlyModeError,
+// so tools that are merely non-destructive are removed too.
+func FilterReadOnlyTools(tools []Tool) []Tool {
+	filtered := make([]Tool, 0, len(tools))
+	for _, tool := range tools {
+		if tool.IsReadOnly() {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// ListToolsResult is the result of a tools/list request.
+type ListToolsResult struct {
+	Tools []Tool `json:"tools"`
+	// NextCursor is set when more results are available.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// CallToolResult is the result of a tools/call request.
+type CallToolResult struct {
+	Content Contents `json:"content"`
+	// StructuredContent is an optional JSON object with the structured form of the result.
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+	// IsError marks the result as a tool-level failure that the model should see,
+	// as opposed to a protocol error in the response.
+	IsError bool `json:"isError,omitempty"`
+}
+
+// NewToolResult creates a successful tool result with the given content blocks.
+// Without content blocks the result still carries an empty "content" array,
+// since the field is required.
+//
+// Example:
+//
+//	result := protocol.NewToolResult(protocol.NewTextContent("42 files found"))
+func NewToolResult(content ...Content) *CallToolResult {
+	if content == nil {
+		content = []Content{}
+	}
+	return &CallToolResult{Content: Contents(content)}
+}
+
+// ToolError is a domain error returned by a tool implementation.
+//
+// Unlike protocol errors (RPCError), which signal infrastructure failures, a ToolError
+// is reported inside a successful response as a result with isError set to true,
+// so the model can see what went wrong and react to it.
+//
+// Example:
+//
+//	func deleteFile(path string) (*protocol.CallToolResult, error) {
+//	    if _, err := os.Stat(path); err != nil {
+//	        return nil, protocol.NewToolError("not_found", "file does not exist")
+//	    }
+//	    ...
+//	}
+type ToolError struct {
+	// Code is a short machine-readable identifier, e.g. "not_found" or "rate_limited".
+	Code string `json:"code,omitempty"`
+	// Message is a human-readable description shown to the model.
+	Message string `json:"message"`
+	// Retriable indicates that calling the tool again may succeed.
+	Retriable bool `json:"retriable,omitempty"`
+	// Data carries optional additional details.
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *ToolError) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return e.Code + ": " + e.Message
+}
+
+// Result converts the error into a tool result with isError set, the message as
+// text content and the full error as structured content.
+func (e *ToolError) Result() *CallToolResult {
+	return &CallToolResult{
+		Content:           Contents{NewTextContent(e.Message)},
+		StructuredContent: e,
+		IsError:           true,
+	}
+}
+
+// NewToolError creates a non-retriable ToolError.
+func NewToolError(code, message string) *ToolError {
+	return &ToolError{Code: code, Message: message}
+}
+
+// ToolErrorResult converts err into an isError tool result if it is, or wraps, a ToolError.
+// Dispatchers use it to tell tool domain errors apart from infrastructure failures,
+// which should become protocol-level errors instead.
+//
+// Example:
+//
+//	result, err := tool.Call(ctx, args)
+//	if err != nil {
+//	    if toolResult, ok := protocol.ToolErrorResult(err); ok {
+//	        return toolResult, nil
+//	    }
+//	    return nil, protocol.NewRPCError(protocol.InternalError, err.Error(), nil)
+//	}
+func ToolErrorResult(err error) (*CallToolResult, bool) {
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) {
+		return nil, false
+	}
+	return toolErr.Result(), true
+}
@@ -0,0 +1,104 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func TestToolReadOnlyAndDestructiveHints(t *testing.T) {
+	tests := []struct {
+		name        string
+		tool        Tool
+		readOnly    bool
+		destructive bool
+	}{
+		{"NoAnnotations", Tool{Name: "a"}, false, true},
+		{"EmptyAnnotations", Tool{Name: "b", Annotations: &ToolAnnotations{}}, false, true},
+		{"ReadOnly", Tool{Name: "c", Annotations: &ToolAnnotations{ReadOnlyHint: boolPtr(true)}}, true, false},
+		{"ReadOnlyIgnoresDestructive", Tool{Name: "d", Annotations: &ToolAnnotations{ReadOnlyHint: boolPtr(true), DestructiveHint: boolPtr(true)}}, true, false},
+		{"NonDestructiveWrite", Tool{Name: "e", Annotations: &ToolAnnotations{ReadOnlyHint: boolPtr(false), DestructiveHint: boolPtr(false)}}, false, false},
+	}
+
+	for _, test := range tests {
+		if got := test.tool.IsReadOnly(); got != test.readOnly {
+			t.Errorf("%s: expected IsReadOnly %v, got %v", test.name, test.readOnly, got)
+		}
+		if got := test.tool.IsDestructive(); got != test.destructive {
+			t.Errorf("%s: expected IsDestructive %v, got %v", test.name, test.destructive, got)
+		}
+	}
+}
+
+func TestFilterReadOnlyTools(t *testing.T) {
+	tools := []Tool{
+		{Name: "read", Annotations: &ToolAnnotations{ReadOnlyHint: boolPtr(true)}},
+		{Name: "write"},
+		{Name: "append", Annotations: &ToolAnnotations{DestructiveHint: boolPtr(false)}},
+		{Name: "delete", Annotations: &ToolAnnotations{DestructiveHint: boolPtr(true)}},
+		{Name: "list", Annotations: &ToolAnnotations{ReadOnlyHint: boolPtr(true)}},
+	}
+
+	var names []string
+	for _, tool := range FilterReadOnlyTools(tools) {
+		names = append(names, tool.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"read", "list"}) {
+		t.Errorf("Expected [read list], got %v", names)
+	}
+
+	if filtered := FilterReadOnlyTools(nil); filtered == nil || len(filtered) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %#v", filtered)
+	}
+}
+
+func TestNewReadOnlyModeError(t *testing.T) {
+	err := NewReadOnlyModeError("delete_file")
+	if err.Code != ReadOnlyMode {
+		t.Errorf("Expected code %d, got %d", ReadOnlyMode, err.Code)
+	}
+	if !reflect.DeepEqual(err.Data, map[string]string{"method": "tools/call", "tool": "delete_file"}) {
+		t.Errorf("Unexpected data: %v", err.Data)
+	}
+}
+
+func TestToolErrorMessage(t *testing.T) {
+	if msg := NewToolError("not_found", "file does not exist").Error(); msg != "not_found: file does not exist" {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+	if msg := (&ToolError{Message: "boom"}).Error(); msg != "boom" {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+}
+
+func TestToolErrorResult(t *testing.T) {
+	toolErr := &ToolError{Code: "rate_limited", Message: "try later", Retriable: true, Data: map[string]int{"retryAfter": 5}}
+	wrapped := fmt.Errorf("search: %w", toolErr)
+
+	result, ok := ToolErrorResult(wrapped)
+	if !ok {
+		t.Fatal("Expected wrapped ToolError to be recognized")
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"content":[{"type":"text","text":"try later"}],"structuredContent":{"code":"rate_limited","message":"try later","retriable":true,"data":{"retryAfter":5}},"isError":true}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, got %s", expected, data)
+	}
+
+	if _, ok := ToolErrorResult(errors.New("database down")); ok {
+		t.Error("Expected plain errors not to be treated as tool errors")
+	}
+	if _, ok := ToolErrorResult(nil); ok {
+		t.Error("Expected nil error not to be treated as a tool error")
+	}
+}
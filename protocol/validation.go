@@ -0,0 +1,25 @@
+package protocol
+
+// validator is implemented by all protocol message types that can check their own correctness.
+type validator interface {
+	validate() error
+}
+
+// ValidateMessage checks an outgoing Request, Response or Notification against
+// the same JSON-RPC / MCP rules applied when unmarshaling inbound messages.
+//
+// Returns ErrUnsupportedMessage if msg is not a message created by this package.
+//
+// Example:
+//
+//	resp := protocol.NewResponse("req-1", result)
+//	if err := protocol.ValidateMessage(resp); err != nil {
+//	    log.Printf("refusing to send invalid response: %v", err)
+//	}
+func ValidateMessage(msg any) error {
+	v, ok := msg.(validator)
+	if !ok {
+		return ErrUnsupportedMessage
+	}
+	return v.validate()
+}
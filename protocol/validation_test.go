@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateMessageValidMessages(t *testing.T) {
+	messages := []any{
+		NewRequest("tools/list", nil, NewID("req-1")),
+		NewResponse(int64(1), "ok"),
+		NewNotification("notifications/initialized", nil),
+	}
+
+	for _, msg := range messages {
+		if err := ValidateMessage(msg); err != nil {
+			t.Errorf("Expected %T to be valid, got %v", msg, err)
+		}
+	}
+}
+
+func TestValidateMessageInvalidMessages(t *testing.T) {
+	messages := []any{
+		NewRequest("rpc.internal", nil, NewID("req-1")),
+		NewResponse(int64(1), nil),
+		NewNotification(" ", nil),
+	}
+
+	for _, msg := range messages {
+		var vErr *ValidationError
+		if err := ValidateMessage(msg); !errors.As(err, &vErr) {
+			t.Errorf("Expected ValidationError for %T, got %v", msg, err)
+		}
+	}
+}
+
+func TestValidateMessageUnsupportedType(t *testing.T) {
+	if err := ValidateMessage(map[string]any{"jsonrpc": "2.0"}); err != ErrUnsupportedMessage {
+		t.Errorf("Expected ErrUnsupportedMessage, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package protocol
+
+// MCP protocol revisions supported by this package.
+const (
+	ProtocolVersion20241105 = "2024-11-05"
+	ProtocolVersion20250326 = "2025-03-26"
+	ProtocolVersion20250618 = "2025-06-18"
+
+	// LatestProtocolVersion is the newest supported revision, offered when
+	// the client requests a version this package doesn't know.
+	LatestProtocolVersion = ProtocolVersion20250618
+)
+
+// Feature identifies a protocol feature that only some revisions support.
+type Feature string
+
+const (
+	// FeatureJSONRPCBatching allows JSON-RPC batches. Added in 2025-03-26, removed in 2025-06-18.
+	FeatureJSONRPCBatching Feature = "jsonrpcBatching"
+	// FeatureAudioContent allows "audio" content blocks.
+	FeatureAudioContent Feature = "audioContent"
+	// FeatureToolAnnotations allows annotations on tool definitions.
+	FeatureToolAnnotations Feature = "toolAnnotations"
+	// FeatureCompletions allows the completions server capability.
+	FeatureCompletions Feature = "completions"
+	// FeatureProgressMessage allows the message field in progress notifications.
+	FeatureProgressMessage Feature = "progressMessage"
+	// FeatureStructuredContent allows structuredContent and outputSchema on tools.
+	FeatureStructuredContent Feature = "structuredContent"
+	// FeatureElicitation allows elicitation/create requests.
+	FeatureElicitation Feature = "elicitation"
+	// FeatureResourceLinks allows "resource_link" content blocks.
+	FeatureResourceLinks Feature = "resourceLinks"
+	// FeatureTitle allows the title field on tools, prompts and resources.
+	FeatureTitle Feature = "title"
+)
+
+// protocolVersions lists the supported revisions, newest first, with the optional features each one allows.
+var protocolVersions = []struct {
+	version  string
+	features []Feature
+}{
+	{ProtocolVersion20250618, []Feature{
+		FeatureAudioContent, FeatureToolAnnotations, FeatureCompletions, FeatureProgressMessage,
+		FeatureStructuredContent, FeatureElicitation, FeatureResourceLinks, FeatureTitle,
+	}},
+	{ProtocolVersion20250326, []Feature{
+		FeatureJSONRPCBatching, FeatureAudioContent, FeatureToolAnnotations, FeatureCompletions, FeatureProgressMessage,
+	}},
+	{ProtocolVersion20241105, nil},
+}
+
+// SupportedProtocolVersions returns the supported protocol revisions, newest first.
+func SupportedProtocolVersions() []string {
+	versions := make([]string, 0, len(protocolVersions))
+	for _, v := range protocolVersions {
+		versions = append(versions, v.version)
+	}
+	return versions
+}
+
+// IsSupportedProtocolVersion reports whether version is a known protocol revision.
+func IsSupportedProtocolVersion(version string) bool {
+	for _, v := range protocolVersions {
+		if v.version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateProtocolVersion returns the version a server should answer with
+// for the version requested in initialize: the requested one if supported,
+// otherwise LatestProtocolVersion, as the specification requires.
+//
+// Example:
+//
+//	version := protocol.NegotiateProtocolVersion(params.ProtocolVersion)
+//	result := protocol.NewInitializeResult(version, caps, info)
+func NegotiateProtocolVersion(requested string) string {
+	if IsSupportedProtocolVersion(requested) {
+		return requested
+	}
+	return LatestProtocolVersion
+}
+
+// SupportsFeature reports whether the negotiated protocol version allows the given feature,
+// so handlers can avoid sending fields that older clients don't understand.
+// Unknown versions support no optional features.
+//
+// Example:
+//
+//	if protocol.SupportsFeature(session.ProtocolVersion(), protocol.FeatureStructuredContent) {
+//	    result.StructuredContent = data
+//	}
+func SupportsFeature(version string, feature Feature) bool {
+	for _, v := range protocolVersions {
+		if v.version != version {
+			continue
+		}
+		for _, f := range v.features {
+			if f == feature {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
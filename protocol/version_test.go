@@ -0,0 +1,57 @@
+package protocol
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSupportedProtocolVersions(t *testing.T) {
+	expected := []string{ProtocolVersion20250618, ProtocolVersion20250326, ProtocolVersion20241105}
+	if got := SupportedProtocolVersions(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if SupportedProtocolVersions()[0] != LatestProtocolVersion {
+		t.Error("Expected newest version to be LatestProtocolVersion")
+	}
+}
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		requested string
+		expected  string
+	}{
+		{ProtocolVersion20241105, ProtocolVersion20241105},
+		{ProtocolVersion20250326, ProtocolVersion20250326},
+		{"2099-01-01", LatestProtocolVersion},
+		{"", LatestProtocolVersion},
+	}
+
+	for _, test := range tests {
+		if got := NegotiateProtocolVersion(test.requested); got != test.expected {
+			t.Errorf("NegotiateProtocolVersion(%q): expected %s, got %s", test.requested, test.expected, got)
+		}
+	}
+}
+
+func TestSupportsFeature(t *testing.T) {
+	tests := []struct {
+		version  string
+		feature  Feature
+		expected bool
+	}{
+		{ProtocolVersion20241105, FeatureAudioContent, false},
+		{ProtocolVersion20250326, FeatureAudioContent, true},
+		{ProtocolVersion20250618, FeatureAudioContent, true},
+		{ProtocolVersion20250326, FeatureJSONRPCBatching, true},
+		{ProtocolVersion20250618, FeatureJSONRPCBatching, false},
+		{ProtocolVersion20250326, FeatureStructuredContent, false},
+		{ProtocolVersion20250618, FeatureElicitation, true},
+		{"2099-01-01", FeatureAudioContent, false},
+	}
+
+	for _, test := range tests {
+		if got := SupportsFeature(test.version, test.feature); got != test.expected {
+			t.Errorf("SupportsFeature(%s, %s): expected %v, got %v", test.version, test.feature, test.expected, got)
+		}
+	}
+}